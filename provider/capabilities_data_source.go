@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CapabilitiesDataSource{}
+
+func NewCapabilitiesDataSource() datasource.DataSource {
+	return &CapabilitiesDataSource{}
+}
+
+// CapabilitiesDataSource defines the data source implementation.
+type CapabilitiesDataSource struct{}
+
+// CapabilitiesDataSourceModel describes the data source data model.
+type CapabilitiesDataSourceModel struct {
+	Formatters  types.List `tfsdk:"formatters"`
+	Expirations types.List `tfsdk:"expirations"`
+}
+
+func (d *CapabilitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capabilities"
+}
+
+func (d *CapabilitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Formatters and expiration times supported by pastebin instances. " +
+			"The pastebin client does not expose instance configuration, so the built-in PrivateBin defaults are returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"formatters": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Supported text formatters",
+				Computed:            true,
+			},
+			"expirations": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Supported expiration times, shortest first",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CapabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CapabilitiesDataSourceModel
+	var diags diag.Diagnostics
+
+	data.Formatters, diags = types.ListValueFrom(ctx, types.StringType, knownFormatters)
+	resp.Diagnostics.Append(diags...)
+
+	data.Expirations, diags = types.ListValueFrom(ctx, types.StringType, knownExpirations)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
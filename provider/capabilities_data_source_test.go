@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilitiesDataSource_Metadata(t *testing.T) {
+	d := &CapabilitiesDataSource{}
+	ctx := context.Background()
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "pastebin",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(ctx, req, resp)
+
+	assert.Equal(t, "pastebin_capabilities", resp.TypeName)
+}
+
+func TestCapabilitiesDataSource_Schema(t *testing.T) {
+	d := &CapabilitiesDataSource{}
+	ctx := context.Background()
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, req, resp)
+
+	require.NotNil(t, resp.Schema.Attributes)
+
+	for _, attrName := range []string{"formatters", "expirations"} {
+		attr, exists := resp.Schema.Attributes[attrName]
+		require.True(t, exists, "Expected attribute %s to be present in schema", attrName)
+		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
+	}
+}
+
+func TestCapabilitiesDataSource_Read(t *testing.T) {
+	d := &CapabilitiesDataSource{}
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	d.Read(ctx, datasource.ReadRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	var data CapabilitiesDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	var formatters, expirations []string
+	require.False(t, data.Formatters.ElementsAs(ctx, &formatters, false).HasError())
+	require.False(t, data.Expirations.ElementsAs(ctx, &expirations, false).HasError())
+
+	assert.Equal(t, knownFormatters, formatters)
+	assert.Equal(t, knownExpirations, expirations)
+}
+
+func TestNewCapabilitiesDataSource(t *testing.T) {
+	dataSource := NewCapabilitiesDataSource()
+	assert.NotNil(t, dataSource)
+
+	_, ok := dataSource.(*CapabilitiesDataSource)
+	assert.True(t, ok)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Defaults of the circuit_breaker provider setting.
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerWindow    = time.Minute
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// circuitOpenError is returned instead of calling a host whose circuit
+// breaker is open.
+type circuitOpenError struct {
+	host     string
+	failures int
+	until    time.Time
+}
+
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("%s failed %d times in a row, not retrying it until %s", e.host, e.failures, e.until.Format(time.RFC3339))
+}
+
+// circuitBreaker fails calls to a host fast once it has failed threshold
+// times in a row within window, until cooldown has passed. It is shared by
+// all resources of a provider, so one unreachable host does not make each
+// of them wait for its own timeout.
+type circuitBreaker struct {
+	host      string
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+
+	mu           sync.Mutex
+	failures     int
+	firstFailure time.Time
+	openUntil    time.Time
+
+	// tripped is set from opening the breaker until a call succeeds, so a
+	// failed trial call after the cooldown opens it again right away.
+	tripped bool
+}
+
+func newCircuitBreaker(host string, threshold int, window, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		host:      host,
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns a circuitOpenError while the breaker is open. A nil breaker
+// allows every call.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.now().Before(b.openUntil) {
+		return &circuitOpenError{host: b.host, failures: b.failures, until: b.openUntil}
+	}
+
+	return nil
+}
+
+// record counts the outcome of a call. Only failures to reach the host
+// count; an interrupted call says nothing about the host.
+func (b *circuitBreaker) record(err error) {
+	if b == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || !isTransportError(err) {
+		b.failures = 0
+		b.tripped = false
+		return
+	}
+
+	now := b.now()
+	if b.failures == 0 || now.Sub(b.firstFailure) > b.window {
+		b.failures = 0
+		b.firstFailure = now
+	}
+
+	b.failures++
+	if b.tripped || b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+		b.tripped = true
+	}
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/RO-29/pastebin-go-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCircuitBreaker returns a breaker with a threshold of 3, a window of a
+// minute and a cooldown of 30 seconds, whose clock is advanced by the
+// returned function.
+func testCircuitBreaker() (*circuitBreaker, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker("https://example.com", 3, time.Minute, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	return b, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	unreachable := &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}
+
+	t.Run("opens after threshold failures", func(t *testing.T) {
+		b, _ := testCircuitBreaker()
+		for i := 0; i < 2; i++ {
+			b.record(unreachable)
+			require.NoError(t, b.allow())
+		}
+
+		b.record(unreachable)
+		err := b.allow()
+		require.Error(t, err)
+		assert.True(t, isTransportError(err))
+	})
+
+	t.Run("closes after cooldown", func(t *testing.T) {
+		b, advance := testCircuitBreaker()
+		for i := 0; i < 3; i++ {
+			b.record(unreachable)
+		}
+
+		advance(31 * time.Second)
+		require.NoError(t, b.allow())
+
+		// A failed trial call opens it again right away
+		b.record(unreachable)
+		assert.Error(t, b.allow())
+
+		advance(31 * time.Second)
+		b.record(nil)
+		b.record(unreachable)
+		assert.NoError(t, b.allow())
+	})
+
+	t.Run("failures outside the window", func(t *testing.T) {
+		b, advance := testCircuitBreaker()
+		b.record(unreachable)
+		b.record(unreachable)
+		advance(2 * time.Minute)
+		b.record(unreachable)
+		assert.NoError(t, b.allow())
+	})
+
+	t.Run("success resets", func(t *testing.T) {
+		b, _ := testCircuitBreaker()
+		b.record(unreachable)
+		b.record(unreachable)
+		b.record(nil)
+		b.record(unreachable)
+		assert.NoError(t, b.allow())
+	})
+
+	t.Run("rejections and cancellations do not count", func(t *testing.T) {
+		b, _ := testCircuitBreaker()
+		for i := 0; i < 3; i++ {
+			b.record(errors.New("paste not found"))
+			b.record(context.Canceled)
+		}
+		assert.NoError(t, b.allow())
+	})
+
+	t.Run("nil breaker", func(t *testing.T) {
+		var b *circuitBreaker
+		b.record(unreachable)
+		assert.NoError(t, b.allow())
+	})
+}
+
+func TestCallWithFailover_CircuitBreaker(t *testing.T) {
+	p := testFailoverProviderData(t)
+	unreachable := &url.Error{Op: "Post", URL: "https://primary.example.com", Err: errors.New("connection refused")}
+
+	for _, host := range append([]url.URL{p.Endpoint}, p.Mirrors...) {
+		p.breakers = append(p.breakers, newCircuitBreaker(host.String(), 1, time.Minute, time.Minute))
+	}
+	p.breakers[0].record(unreachable)
+
+	var calls []*pastebin.Client
+	call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+		calls = append(calls, client)
+		return a, nil
+	}
+
+	clients := p.hostClients(nil)
+	value, err := callWithFailover(context.Background(), clients, opCreatePaste, call, "paste", 1, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "paste", value)
+	require.Len(t, calls, 1)
+	assert.Same(t, clients[1].client, calls[0])
+}
+
+func TestCallWithFailover_CircuitBreakerSlotTimeout(t *testing.T) {
+	p := testFailoverProviderData(t)
+	p.Mirrors = nil
+	p.breakers = []*circuitBreaker{newCircuitBreaker(p.Endpoint.String(), 1, time.Minute, time.Minute)}
+	p.requestSlots = make(chan struct{}, 1)
+	p.requestSlots <- struct{}{}
+
+	call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+		return a, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := callWithFailover(ctx, p.hostClients(nil), opCreatePaste, call, "paste", 1, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The call never reached the host, so its breaker stays closed
+	assert.NoError(t, p.breakers[0].allow())
+}
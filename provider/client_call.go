@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+// callWithContext calls a client method and returns as soon as ctx is
+// done, even if the method does not watch ctx itself. This keeps
+// cancelling Terraform prompt during large uploads. An abandoned call
+// finishes in the background and its result is discarded.
+func callWithContext[A, B, R any](ctx context.Context, call func(context.Context, A, B) (R, error), a A, b B) (R, error) {
+	var zero R
+
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type result struct {
+		value R
+		err   error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		value, err := call(ctx, a, b)
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Operation names passed to a RequestHook.
+const (
+	opCreatePaste = "CreatePaste"
+	opShowPaste   = "ShowPaste"
+)
+
+// RequestHook observes a client call: the operation, how long it took,
+// including any wait for max_concurrent_requests, and the error it
+// returned, if any. With failover it is called once per host tried.
+type RequestHook func(op string, dur time.Duration, err error)
+
+// hostClient is a client for one of the provider hosts.
+type hostClient struct {
+	host      url.URL
+	client    *pastebin.Client
+	onRequest RequestHook
+	breaker   *circuitBreaker
+
+	// slots limits the client calls in flight across all hosts, see
+	// acquireSlot. Nil when unlimited.
+	slots chan struct{}
+}
+
+// hostClients returns a client for each provider host with the given extra
+// headers and client options, the primary host first.
+func (p *ProviderData) hostClients(headers map[string]string, options ...pastebin.Option) []hostClient {
+	clients := []hostClient{{host: p.Endpoint, client: p.clientWithHeaders(headers, options...), onRequest: p.OnRequest, breaker: p.breaker(0), slots: p.requestSlots}}
+
+	for i, mirror := range p.Mirrors {
+		client := p.mirrorClients[i]
+		if len(headers) > 0 || len(options) > 0 {
+			client = p.newClientFor(mirror, headers, options...)
+		}
+		clients = append(clients, hostClient{host: mirror, client: client, onRequest: p.OnRequest, breaker: p.breaker(i + 1), slots: p.requestSlots})
+	}
+
+	return clients
+}
+
+// breaker returns the circuit breaker of the i-th host, the primary host
+// first, or nil when circuit breaking is disabled.
+func (p *ProviderData) breaker(i int) *circuitBreaker {
+	if i >= len(p.breakers) {
+		return nil
+	}
+
+	return p.breakers[i]
+}
+
+// acquireSlot waits for a free slot in slots, returning the error of ctx
+// when it is done first. A nil slots is never full.
+func acquireSlot(ctx context.Context, slots chan struct{}) error {
+	if slots == nil {
+		return nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	tflog.Debug(ctx, "max_concurrent_requests reached, waiting for a client call to finish")
+
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseSlot frees a slot taken by acquireSlot.
+func releaseSlot(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
+
+// movePasteURL returns pasteURL moved to host, or false when pasteURL is not
+// on one of the provider hosts and so cannot be read from a mirror.
+func (p *ProviderData) movePasteURL(pasteURL url.URL, host url.URL) (url.URL, bool) {
+	for _, origin := range append([]url.URL{p.Endpoint}, p.Mirrors...) {
+		if pasteURL.Scheme != origin.Scheme || pasteURL.Host != origin.Host || !strings.HasPrefix(pasteURL.Path, origin.Path) {
+			continue
+		}
+
+		moved := host
+		moved.Path = strings.TrimSuffix(host.Path, "/") + "/" + strings.TrimPrefix(strings.TrimPrefix(pasteURL.Path, origin.Path), "/")
+		moved.RawPath = ""
+		moved.RawQuery = pasteURL.RawQuery
+		moved.Fragment = pasteURL.Fragment
+		moved.RawFragment = pasteURL.RawFragment
+		return moved, true
+	}
+
+	return url.URL{}, false
+}
+
+// callWithFailover calls a client method with callWithContext on each of
+// clients in turn, moving on only when a host cannot be reached. When move
+// is set, it moves the first argument to each host and failover stops at
+// the first host it cannot be moved to. Each call is reported to the
+// request hook of its client as op.
+func callWithFailover[A, B, R any](ctx context.Context, clients []hostClient, op string, call func(*pastebin.Client, context.Context, A, B) (R, error), a A, b B, move func(A, url.URL) (A, bool)) (R, error) {
+	var result R
+	var err error
+
+	for i, hc := range clients {
+		arg := a
+		if move != nil && i > 0 {
+			var ok bool
+			if arg, ok = move(a, hc.host); !ok {
+				break
+			}
+		}
+
+		// Skip hosts that keep failing rather than waiting for them to
+		// time out again
+		if err = hc.breaker.allow(); err != nil {
+			tflog.Warn(ctx, "pastebin host circuit breaker open, skipping it", map[string]interface{}{
+				"host": hc.host.Host,
+			})
+			continue
+		}
+
+		// Only read the clock when someone is listening
+		var start time.Time
+		if hc.onRequest != nil {
+			start = time.Now()
+		}
+
+		// The slot is taken and freed by the call itself, so a call that
+		// callWithContext gives up on keeps it until it really finishes
+		var sent atomic.Bool
+		result, err = callWithContext(ctx, func(ctx context.Context, a A, b B) (R, error) {
+			if err := acquireSlot(ctx, hc.slots); err != nil {
+				var zero R
+				return zero, err
+			}
+			defer releaseSlot(hc.slots)
+
+			sent.Store(true)
+			return call(hc.client, ctx, a, b)
+		}, arg, b)
+
+		// Timing out while waiting for a slot says nothing about the host
+		if sent.Load() {
+			hc.breaker.record(err)
+		}
+		if hc.onRequest != nil {
+			hc.onRequest(op, time.Since(start), err)
+		}
+		if err == nil {
+			tflog.Debug(ctx, "request served", map[string]interface{}{
+				"host": hc.host.Host,
+			})
+			return result, nil
+		}
+
+		if !isTransportError(err) || ctx.Err() != nil {
+			return result, err
+		}
+
+		if i < len(clients)-1 {
+			tflog.Warn(ctx, "pastebin host unreachable, trying the next host", map[string]interface{}{
+				"host": hc.host.Host,
+			})
+		}
+	}
+
+	return result, err
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+func TestCallWithContext(t *testing.T) {
+	call := func(ctx context.Context, a string, b int) (string, error) {
+		return a, nil
+	}
+
+	value, err := callWithContext(context.Background(), call, "paste", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "paste", value)
+}
+
+func TestCallWithContext_Error(t *testing.T) {
+	expected := errors.New("paste does not exist")
+	call := func(ctx context.Context, a string, b int) (string, error) {
+		return "", expected
+	}
+
+	_, err := callWithContext(context.Background(), call, "paste", 1)
+
+	assert.ErrorIs(t, err, expected)
+}
+
+func TestCallWithContext_CancelledMidCall(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	// A call that ignores its context, like a client that drops it
+	call := func(ctx context.Context, a string, b int) (string, error) {
+		close(started)
+		<-release
+		return a, nil
+	}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := callWithContext(ctx, call, "paste", 1)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, isTransportError(err))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCallWithContext_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	call := func(ctx context.Context, a string, b int) (string, error) {
+		called = true
+		return a, nil
+	}
+
+	_, err := callWithContext(ctx, call, "paste", 1)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+// testFailoverProviderData returns provider data with a primary host and
+// two mirrors.
+func testFailoverProviderData(t *testing.T) *ProviderData {
+	t.Helper()
+
+	var hosts []url.URL
+	for _, h := range []string{"https://primary.example.com", "https://mirror1.example.com/paste", "https://mirror2.example.com"} {
+		u, err := url.Parse(h)
+		require.NoError(t, err)
+		hosts = append(hosts, *u)
+	}
+
+	p := &ProviderData{Endpoint: hosts[0], Mirrors: hosts[1:]}
+	p.Client = p.newClient(nil)
+	for _, mirror := range p.Mirrors {
+		p.mirrorClients = append(p.mirrorClients, p.newClientFor(mirror, nil))
+	}
+
+	return p
+}
+
+func TestProviderData_HostClients(t *testing.T) {
+	p := testFailoverProviderData(t)
+
+	// Without headers or options the configured clients are reused
+	clients := p.hostClients(nil)
+	require.Len(t, clients, 3)
+	assert.Same(t, p.Client, clients[0].client)
+	assert.Same(t, p.mirrorClients[0], clients[1].client)
+
+	clients = p.hostClients(nil, pastebin.WithBasicAuth("reader", "secret"))
+	require.Len(t, clients, 3)
+	assert.NotSame(t, p.Client, clients[0].client)
+	assert.NotSame(t, p.mirrorClients[0], clients[1].client)
+	assert.NotSame(t, p.mirrorClients[1], clients[2].client)
+	assert.Equal(t, p.Mirrors[1], clients[2].host)
+}
+
+func TestCallWithFailover(t *testing.T) {
+	unreachable := &url.Error{Op: "Post", URL: "https://example.com", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "primary serves",
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "mirror serves",
+			errs:          []error{unreachable, unreachable, nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "no failover when the paste is missing",
+			errs:          []error{errors.New("paste does not exist")},
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:          "every host unreachable",
+			errs:          []error{unreachable, unreachable, unreachable},
+			expectedCalls: 3,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testFailoverProviderData(t)
+			clients := p.hostClients(nil)
+
+			var calls []*pastebin.Client
+			call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+				calls = append(calls, client)
+				return a, tt.errs[len(calls)-1]
+			}
+
+			value, err := callWithFailover(context.Background(), clients, "Test", call, "paste", 1, nil)
+
+			require.Len(t, calls, tt.expectedCalls)
+			for i, client := range calls {
+				assert.Same(t, clients[i].client, client)
+			}
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "paste", value)
+		})
+	}
+}
+
+func TestCallWithFailover_MovesPasteURL(t *testing.T) {
+	p := testFailoverProviderData(t)
+	unreachable := &url.Error{Op: "Get", URL: "https://primary.example.com", Err: errors.New("connection refused")}
+
+	var urls []string
+	call := func(client *pastebin.Client, ctx context.Context, pasteURL url.URL, b int) (string, error) {
+		urls = append(urls, pasteURL.String())
+		if len(urls) == 1 {
+			return "", unreachable
+		}
+		return pasteURL.RawQuery, nil
+	}
+
+	pasteURL := *p.pasteURL("abcd1234", "key")
+	value, err := callWithFailover(context.Background(), p.hostClients(nil), opShowPaste, call, pasteURL, 1, p.movePasteURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, "abcd1234", value)
+	assert.Equal(t, []string{
+		"https://primary.example.com?abcd1234#key",
+		"https://mirror1.example.com/paste/?abcd1234#key",
+	}, urls)
+}
+
+func TestCallWithFailover_OnRequest(t *testing.T) {
+	p := testFailoverProviderData(t)
+	unreachable := &url.Error{Op: "Get", URL: "https://primary.example.com", Err: errors.New("connection refused")}
+
+	var ops []string
+	var errs []error
+	p.OnRequest = func(op string, dur time.Duration, err error) {
+		assert.GreaterOrEqual(t, dur, time.Duration(0))
+		ops = append(ops, op)
+		errs = append(errs, err)
+	}
+
+	calls := 0
+	call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", unreachable
+		}
+		return a, nil
+	}
+
+	_, err := callWithFailover(context.Background(), p.hostClients(nil), opCreatePaste, call, "paste", 1, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{opCreatePaste, opCreatePaste}, ops)
+	assert.Equal(t, []error{unreachable, nil}, errs)
+}
+
+func TestCallWithFailover_MaxConcurrentRequests(t *testing.T) {
+	p := testFailoverProviderData(t)
+	p.requestSlots = make(chan struct{}, 2)
+	clients := p.hostClients(nil)
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return a, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := callWithFailover(context.Background(), clients, opCreatePaste, call, "paste", 1, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, maxInFlight)
+	assert.Empty(t, p.requestSlots)
+}
+
+func TestCallWithFailover_MaxConcurrentRequestsCancelled(t *testing.T) {
+	p := testFailoverProviderData(t)
+	p.requestSlots = make(chan struct{}, 1)
+	p.requestSlots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	called := false
+	call := func(client *pastebin.Client, ctx context.Context, a string, b int) (string, error) {
+		called = true
+		return a, nil
+	}
+
+	_, err := callWithFailover(ctx, p.hostClients(nil), opCreatePaste, call, "paste", 1, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called)
+}
+
+func TestMovePasteURL(t *testing.T) {
+	p := testFailoverProviderData(t)
+
+	pasteURL, err := url.Parse("https://mirror1.example.com/paste/?abcd1234#key")
+	require.NoError(t, err)
+	moved, ok := p.movePasteURL(*pasteURL, p.Mirrors[1])
+	require.True(t, ok)
+	assert.Equal(t, "https://mirror2.example.com/?abcd1234#key", moved.String())
+
+	// Pastes on other instances are not on the mirrors
+	pasteURL, err = url.Parse("https://elsewhere.example.com/?abcd1234#key")
+	require.NoError(t, err)
+	_, ok = p.movePasteURL(*pasteURL, p.Mirrors[0])
+	assert.False(t, ok)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxDebugBodySize bounds how much of a response body debug_http logs.
+const maxDebugBodySize = 1024
+
+// httpTransport returns the transport for the HTTP requests the provider
+// sends itself, logging them when DebugHTTP is set.
+func (p *ProviderData) httpTransport() http.RoundTripper {
+	transport := redirectTransport(p.TLSConfig)
+	if !p.DebugHTTP {
+		return transport
+	}
+
+	return &debugTransport{next: transport, secrets: p.Secrets}
+}
+
+// debugTransport logs requests and responses at the DEBUG level, with
+// sensitive headers and secrets redacted.
+type debugTransport struct {
+	next    http.RoundTripper
+	secrets []string
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	logged := req.Clone(ctx)
+	redactHeaders(logged.Header)
+	if dump, err := httputil.DumpRequestOut(logged, false); err == nil {
+		t.log(ctx, "sending HTTP request", dump)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dump, err := httputil.DumpResponse(redactedResponse(resp), false)
+	if err != nil {
+		return resp, nil
+	}
+
+	// Peek at the start of the body, then hand the whole body on
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxDebugBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+
+	t.log(ctx, "received HTTP response", append(dump, body...))
+
+	return resp, nil
+}
+
+func (t *debugTransport) log(ctx context.Context, msg string, dump []byte) {
+	tflog.Debug(ctx, msg, map[string]interface{}{
+		"http": redactString(string(dump), t.secrets...),
+	})
+}
+
+// redactedResponse returns a copy of resp without its body and with the
+// values of sensitive headers redacted.
+func redactedResponse(resp *http.Response) *http.Response {
+	logged := *resp
+	logged.Header = resp.Header.Clone()
+	logged.Body = http.NoBody
+	redactHeaders(logged.Header)
+
+	return &logged
+}
+
+// redactHeaders replaces the values of the sensitive headers in header.
+func redactHeaders(header http.Header) {
+	for name := range header {
+		if isSensitiveHeader(name) || http.CanonicalHeaderKey(name) == "Set-Cookie" {
+			header[name] = []string{redacted}
+		}
+	}
+}
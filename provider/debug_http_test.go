@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderData_HTTPTransport(t *testing.T) {
+	p := &ProviderData{}
+	assert.Equal(t, http.DefaultTransport, p.httpTransport())
+
+	p.DebugHTTP = true
+	assert.IsType(t, &debugTransport{}, p.httpTransport())
+}
+
+func TestDebugTransport(t *testing.T) {
+	body := strings.Repeat("a", maxDebugBodySize) + "tail"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-value"})
+		w.Header().Set("X-Echo", r.Header.Get("X-Team"))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	p := &ProviderData{DebugHTTP: true, Secrets: []string{"team-secret"}}
+	client := newRedirectClient(p.httpTransport())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token-value")
+	req.Header.Set("X-Team", "team-secret")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The caller still gets the whole body
+	received, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(received))
+
+	logs := output.String()
+	assert.Contains(t, logs, "sending HTTP request")
+	assert.Contains(t, logs, "received HTTP response")
+	assert.Contains(t, logs, redacted)
+	assert.NotContains(t, logs, "token-value")
+	assert.NotContains(t, logs, "cookie-value")
+	assert.NotContains(t, logs, "team-secret")
+	assert.NotContains(t, logs, "tail")
+}
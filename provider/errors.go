@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// redacted replaces secrets in error messages.
+const redacted = "[REDACTED]"
+
+var (
+	// urlFragmentPattern matches the fragment of a URL, which holds the
+	// master key of a paste.
+	urlFragmentPattern = regexp.MustCompile(`(://[^\s"'#]*)#[^\s"']+`)
+
+	// authCredentialsPattern matches the credentials of an Authorization
+	// header value.
+	authCredentialsPattern = regexp.MustCompile(`(?i)\b(basic|bearer)\s+[A-Za-z0-9+/=._~-]+`)
+)
+
+// sensitiveHeaders are the headers, in canonical form, whose values are
+// redacted from error messages.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"X-Api-Key",
+}
+
+// isTransportError reports whether err was caused by failing to talk to the
+// pastebin instance, as opposed to the instance rejecting the request.
+func isTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var circuitErr *circuitOpenError
+	if errors.As(err, &circuitErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// decryptionFailure is the message of the error returned by AES-GCM when a
+// paste is opened with the wrong password or key. crypto/cipher does not
+// export the error itself.
+const decryptionFailure = "message authentication failed"
+
+// isDecryptionError reports whether err was caused by failing to decrypt a
+// paste the instance did return, as opposed to the paste not existing.
+func isDecryptionError(err error) bool {
+	return strings.Contains(err.Error(), decryptionFailure)
+}
+
+// pasteNotFound is the message PrivateBin returns for a paste that does not
+// exist, has expired or has been deleted, which includes burned pastes.
+const pasteNotFound = "Paste does not exist, has expired or has been deleted."
+
+// isPasteNotFoundError reports whether err was caused by the instance not
+// having the paste.
+func isPasteNotFoundError(err error) bool {
+	return !isTransportError(err) && strings.Contains(err.Error(), pasteNotFound)
+}
+
+// burnConfirmationRequired is the message of the error the pastebin client
+// returns when a burn-after-reading paste is read without ConfirmBurn. The
+// client does not export the error itself.
+const burnConfirmationRequired = "paste is burn after reading, confirm burn to read it"
+
+// isBurnConfirmationError reports whether err was caused by reading a
+// burn-after-reading paste without confirming the burn.
+func isBurnConfirmationError(err error) bool {
+	return !isTransportError(err) && strings.Contains(err.Error(), burnConfirmationRequired)
+}
+
+// redactError returns the message of err with the given secrets, URL
+// fragments and authorization credentials replaced, so it can be shown in
+// diagnostics.
+func redactError(err error, secrets ...string) string {
+	return redactString(err.Error(), secrets...)
+}
+
+// redactString returns msg with the given secrets, URL fragments and
+// authorization credentials replaced.
+func redactString(msg string, secrets ...string) string {
+	// Replace longer secrets first so a secret containing another is not
+	// partially revealed.
+	secrets = slices.Clone(secrets)
+	slices.SortFunc(secrets, func(a, b string) int { return len(b) - len(a) })
+	for _, secret := range secrets {
+		if secret != "" {
+			msg = strings.ReplaceAll(msg, secret, redacted)
+		}
+	}
+
+	msg = urlFragmentPattern.ReplaceAllString(msg, "${1}#"+redacted)
+	return authCredentialsPattern.ReplaceAllString(msg, "${1} "+redacted)
+}
+
+// isSensitiveHeader reports whether the value of the named header is
+// redacted from error messages.
+func isSensitiveHeader(name string) bool {
+	return slices.ContainsFunc(sensitiveHeaders, func(h string) bool { return strings.EqualFold(h, name) })
+}
+
+// sensitiveHeaderValues returns the values of the sensitive headers among
+// headers.
+func sensitiveHeaderValues(headers map[string]string) []string {
+	var values []string
+	for name, value := range headers {
+		if isSensitiveHeader(name) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransportError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "paste not found",
+			err:      errors.New("paste does not exist, has expired or has been deleted"),
+			expected: false,
+		},
+		{
+			name: "connection refused",
+			err: fmt.Errorf("cannot send request: %w", &url.Error{
+				Op:  "Get",
+				URL: "https://example.com/?abc",
+				Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			}),
+			expected: true,
+		},
+		{
+			name:     "DNS failure",
+			err:      &net.DNSError{Err: "no such host", Name: "example.invalid"},
+			expected: true,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("cannot read paste: %w", context.DeadlineExceeded),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTransportError(tt.err))
+		})
+	}
+}
+
+// decryptWithWrongKey returns the error AES-GCM gives when a paste is
+// opened with a key derived from the wrong password.
+func decryptWithWrongKey(t *testing.T) error {
+	t.Helper()
+
+	newGCM := func(key []byte) cipher.AEAD {
+		block, err := aes.NewCipher(key)
+		require.NoError(t, err)
+		gcm, err := cipher.NewGCM(block)
+		require.NoError(t, err)
+		return gcm
+	}
+
+	nonce := make([]byte, 12)
+	sealed := newGCM(bytes.Repeat([]byte{1}, 32)).Seal(nil, nonce, []byte("secret paste"), nil)
+	_, err := newGCM(bytes.Repeat([]byte{2}, 32)).Open(nil, nonce, sealed, nil)
+	require.Error(t, err)
+
+	return err
+}
+
+func TestIsDecryptionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "wrong password",
+			err:      fmt.Errorf("cannot decrypt paste: %w", decryptWithWrongKey(t)),
+			expected: true,
+		},
+		{
+			name:     "paste not found",
+			err:      errors.New("paste does not exist, has expired or has been deleted"),
+			expected: false,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("cannot read paste: %w", context.DeadlineExceeded),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isDecryptionError(tt.err))
+			assert.False(t, isTransportError(tt.err) && tt.expected)
+		})
+	}
+}
+
+func TestIsPasteNotFoundError(t *testing.T) {
+	assert.True(t, isPasteNotFoundError(errors.New("server error: Paste does not exist, has expired or has been deleted.")))
+	assert.False(t, isPasteNotFoundError(errors.New("server error: Invalid data.")))
+	assert.False(t, isPasteNotFoundError(errors.New("cannot decode response: unexpected EOF")))
+	assert.False(t, isPasteNotFoundError(fmt.Errorf("cannot decrypt paste: %w", decryptWithWrongKey(t))))
+}
+
+func TestIsBurnConfirmationError(t *testing.T) {
+	assert.True(t, isBurnConfirmationError(errors.New("paste is burn after reading, confirm burn to read it")))
+	assert.True(t, isBurnConfirmationError(fmt.Errorf("cannot show paste: %w", errors.New("paste is burn after reading, confirm burn to read it"))))
+	assert.False(t, isBurnConfirmationError(errors.New("this paste will be Burned after reading")))
+	assert.False(t, isBurnConfirmationError(errors.New("server error: paste could not be burned, storage is read-only")))
+	assert.False(t, isBurnConfirmationError(errors.New("paste does not exist, has expired or has been deleted")))
+	assert.False(t, isBurnConfirmationError(&url.Error{Op: "Get", URL: "https://burn.example.com", Err: errors.New("connection refused")}))
+}
+
+func TestRedactError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		secrets  []string
+		expected string
+	}{
+		{
+			name:     "password",
+			err:      errors.New(`request failed: {"password":"hunter2"}`),
+			secrets:  []string{"hunter2"},
+			expected: `request failed: {"password":"[REDACTED]"}`,
+		},
+		{
+			name:     "longer secret first",
+			err:      errors.New("bad credentials hunter2hunter2"),
+			secrets:  []string{"hunter2", "hunter2hunter2"},
+			expected: "bad credentials [REDACTED]",
+		},
+		{
+			name:     "empty secret ignored",
+			err:      errors.New("paste not found"),
+			secrets:  []string{""},
+			expected: "paste not found",
+		},
+		{
+			name:     "master key in URL",
+			err:      errors.New(`Get "https://paste.example.com/?abc123#SecretKey": connection refused`),
+			expected: `Get "https://paste.example.com/?abc123#[REDACTED]": connection refused`,
+		},
+		{
+			name:     "authorization header",
+			err:      errors.New("request headers: Authorization: Basic dXNlcjpodW50ZXIy"),
+			expected: "request headers: Authorization: Basic [REDACTED]",
+		},
+		{
+			name:     "bearer token",
+			err:      errors.New("rejected bearer abc.def-ghi"),
+			expected: "rejected bearer [REDACTED]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactError(tt.err, tt.secrets...))
+		})
+	}
+}
+
+func TestProviderData_RedactError(t *testing.T) {
+	providerData := &ProviderData{Secrets: []string{"basic-auth-password", "default-password"}}
+	err := errors.New("basic-auth-password default-password paste-password")
+
+	msg := providerData.redactError(err, "paste-password")
+
+	assert.Equal(t, "[REDACTED] [REDACTED] [REDACTED]", msg)
+}
+
+func TestSensitiveHeaderValues(t *testing.T) {
+	values := sensitiveHeaderValues(map[string]string{
+		"authorization": "Bearer token",
+		"X-API-Key":     "key",
+		"X-Request-Id":  "request",
+	})
+
+	assert.ElementsMatch(t, []string{"Bearer token", "key"}, values)
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrivateBin is an in-memory PrivateBin instance implementing the JSON
+// API the client uses: creating a paste, reading it back and deleting it
+// with its delete token. Pastes are
+// stored encrypted as the client sent them, so only their unencrypted
+// parameters can be inspected.
+type fakePrivateBin struct {
+	*httptest.Server
+
+	// version is the PrivateBin version the front page reports, if any.
+	// Set it before sending requests.
+	version string
+
+	// gzipResponses compresses responses to requests accepting gzip, like
+	// a compressing reverse proxy. Set it before sending requests.
+	gzipResponses bool
+
+	mu       sync.Mutex
+	pastes   map[string]fakePaste
+	requests []fakeRequest
+}
+
+// fakePaste is a paste stored by fakePrivateBin.
+type fakePaste struct {
+	AData            json.RawMessage
+	CT               string
+	Meta             json.RawMessage
+	BurnAfterReading bool
+}
+
+// fakeRequest is a request received by fakePrivateBin. The paste fields
+// are only set for create requests.
+type fakeRequest struct {
+	Method           string
+	Header           http.Header
+	Formatter        string
+	Expire           string
+	Compression      string
+	OpenDiscussion   bool
+	BurnAfterReading bool
+}
+
+// newFakePrivateBin starts a fakePrivateBin that is closed when the test
+// ends.
+func newFakePrivateBin(t *testing.T) *fakePrivateBin {
+	t.Helper()
+
+	f := &fakePrivateBin{pastes: make(map[string]fakePaste)}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakePrivateBin) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// Without this header PrivateBin serves its web page instead
+	if r.Header.Get("X-Requested-With") != "JSONHttpRequest" {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<!DOCTYPE html><title>PrivateBin</title>")
+		if f.version != "" {
+			fmt.Fprintf(w, `<script src="js/privatebin.js?%s"></script>`, f.version)
+		}
+		return
+	}
+
+	if f.gzipResponses && strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		w = gzipResponseWriter{ResponseWriter: w, writer: gz}
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		f.create(w, r)
+	case http.MethodGet:
+		f.read(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakePrivateBin) create(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		V     int             `json:"v"`
+		AData json.RawMessage `json:"adata"`
+		CT    string          `json:"ct"`
+		Meta  json.RawMessage `json:"meta"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.V != 2 {
+		writeFakeError(w, "Invalid data.")
+		return
+	}
+
+	// adata holds the cipher parameters, ending with the compression, then
+	// the formatter, open discussion and burn after reading flags
+	var adata []json.RawMessage
+	var cipher []interface{}
+	var meta struct {
+		Expire string `json:"expire"`
+	}
+	req := fakeRequest{Method: r.Method, Header: r.Header.Clone()}
+	if json.Unmarshal(body.AData, &adata) != nil || len(adata) != 4 ||
+		json.Unmarshal(adata[0], &cipher) != nil || len(cipher) != 8 ||
+		json.Unmarshal(adata[1], &req.Formatter) != nil ||
+		json.Unmarshal(body.Meta, &meta) != nil {
+		writeFakeError(w, "Invalid data.")
+		return
+	}
+	req.Expire = meta.Expire
+	req.Compression, _ = cipher[7].(string)
+	req.OpenDiscussion = fakeFlag(adata[2])
+	req.BurnAfterReading = fakeFlag(adata[3])
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, req)
+
+	id := fmt.Sprintf("%016x", len(f.requests))
+	f.pastes[id] = fakePaste{
+		AData:            body.AData,
+		CT:               body.CT,
+		Meta:             body.Meta,
+		BurnAfterReading: req.BurnAfterReading,
+	}
+
+	writeFakeResponse(w, map[string]interface{}{
+		"status":      0,
+		"id":          id,
+		"url":         "/?" + id,
+		"deletetoken": fakeDeleteToken(id),
+	})
+}
+
+func (f *fakePrivateBin) read(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pasteid")
+	if id == "" {
+		id = r.URL.RawQuery
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, fakeRequest{Method: r.Method, Header: r.Header.Clone()})
+
+	paste, ok := f.pastes[id]
+	if !ok {
+		writeFakeError(w, "Paste does not exist, has expired or has been deleted.")
+		return
+	}
+
+	if token, ok := r.URL.Query()["deletetoken"]; ok {
+		if token[0] != fakeDeleteToken(id) {
+			writeFakeError(w, "Wrong deletion token. Paste was not deleted.")
+			return
+		}
+
+		delete(f.pastes, id)
+		writeFakeResponse(w, map[string]interface{}{"status": 0, "id": id})
+		return
+	}
+
+	// Like PrivateBin, burn after reading pastes are deleted when they are
+	// first read
+	if paste.BurnAfterReading {
+		delete(f.pastes, id)
+	}
+
+	writeFakeResponse(w, map[string]interface{}{
+		"status":        0,
+		"id":            id,
+		"url":           "/?" + id,
+		"v":             2,
+		"adata":         paste.AData,
+		"ct":            paste.CT,
+		"meta":          paste.Meta,
+		"comments":      []interface{}{},
+		"comment_count": 0,
+	})
+}
+
+// deletePaste removes a paste as if it expired or was deleted outside of
+// Terraform.
+func (f *fakePrivateBin) deletePaste(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.pastes, id)
+}
+
+// hasPaste reports whether the paste with the given ID is stored.
+func (f *fakePrivateBin) hasPaste(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.pastes[id]
+	return ok
+}
+
+// createRequests returns the create requests received so far.
+func (f *fakePrivateBin) createRequests() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var requests []fakeRequest
+	for _, req := range f.requests {
+		if req.Method == http.MethodPost {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+// providerData configures the provider against the fake with the given
+// extra provider attributes.
+func (f *fakePrivateBin) providerData(t *testing.T, values map[string]tftypes.Value) *ProviderData {
+	t.Helper()
+
+	config := map[string]tftypes.Value{
+		"host":                tftypes.NewValue(tftypes.String, f.URL),
+		"allow_insecure_http": tftypes.NewValue(tftypes.Bool, true),
+	}
+	for name, value := range values {
+		config[name] = value
+	}
+
+	resp := &provider.ConfigureResponse{}
+	(&PastebinProvider{version: "test"}).Configure(context.Background(), provider.ConfigureRequest{
+		Config: testProviderConfig(t, config),
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+
+	return providerData
+}
+
+func writeFakeResponse(w http.ResponseWriter, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, message string) {
+	writeFakeResponse(w, map[string]interface{}{"status": 1, "message": message})
+}
+
+// gzipResponseWriter writes the response body through a gzip writer.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	writer *gzip.Writer
+}
+
+func (w gzipResponseWriter) Write(b []byte) (int, error) {
+	return w.writer.Write(b)
+}
+
+// fakeDeleteToken returns the delete token of the paste with the given ID.
+func fakeDeleteToken(id string) string {
+	token := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(token[:])
+}
+
+// fakeFlag reports whether an adata flag is set. PrivateBin sends them as
+// 0 and 1.
+func fakeFlag(raw json.RawMessage) bool {
+	return string(raw) == "1" || string(raw) == "true"
+}
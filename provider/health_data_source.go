@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HealthDataSource{}
+
+// healthCheckTimeout bounds how long the health check waits for the
+// instance.
+const healthCheckTimeout = 10 * time.Second
+
+// maxHealthBodySize bounds how much of the front page is searched for the
+// instance version.
+const maxHealthBodySize = 1 << 20
+
+// privateBinVersionPattern matches the version PrivateBin appends to its
+// script URLs to bust caches, as in js/privatebin.js?1.7.1.
+var privateBinVersionPattern = regexp.MustCompile(`privatebin\.js\?v?([0-9][0-9A-Za-z.+-]*)`)
+
+func NewHealthDataSource() datasource.DataSource {
+	return &HealthDataSource{}
+}
+
+// HealthDataSource defines the data source implementation.
+type HealthDataSource struct {
+	providerData *ProviderData
+}
+
+// HealthDataSourceModel describes the data source data model.
+type HealthDataSourceModel struct {
+	Host      types.String `tfsdk:"host"`
+	Reachable types.Bool   `tfsdk:"reachable"`
+	Version   types.String `tfsdk:"version"`
+	LatencyMs types.Int64  `tfsdk:"latency_ms"`
+	Error     types.String `tfsdk:"error"`
+}
+
+// healthResult is the outcome of a health check.
+type healthResult struct {
+	Reachable bool
+	Version   string
+	Latency   time.Duration
+	Err       error
+}
+
+func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether the configured pastebin instance answers, for use in preconditions before creating pastes. " +
+			"An unreachable instance is reported through `reachable` rather than failing the plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Host URL that was checked",
+				Computed:            true,
+			},
+			"reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the instance answered with a status below 500. Authentication failures still count as reachable",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "PrivateBin version of the instance, taken from its front page. Null when the instance does not expose it",
+				Computed:            true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Time until the instance answered, in milliseconds. Null when it is not reachable",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Why the instance is not reachable. Null when it is",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HealthDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	client := newRedirectClient(d.providerData.httpTransport())
+	result := checkHealth(ctx, client, d.providerData.Endpoint, d.providerData.Headers)
+
+	data.Host = types.StringValue(d.providerData.Endpoint.String())
+	data.Reachable = types.BoolValue(result.Reachable)
+	data.Version = types.StringNull()
+	if result.Version != "" {
+		data.Version = types.StringValue(result.Version)
+	}
+
+	data.LatencyMs = types.Int64Null()
+	data.Error = types.StringNull()
+	if result.Reachable {
+		data.LatencyMs = types.Int64Value(result.Latency.Milliseconds())
+	} else {
+		data.Error = types.StringValue(d.providerData.redactError(result.Err))
+	}
+
+	tflog.Debug(ctx, "checked pastebin instance health", map[string]interface{}{
+		"reachable":  result.Reachable,
+		"latency_ms": result.Latency.Milliseconds(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkHealth requests the front page of the instance at endpoint with the
+// given headers. Failures are returned in the result rather than as an
+// error.
+func checkHealth(ctx context.Context, client *http.Client, endpoint url.URL, headers []headerField) healthResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return healthResult{Err: err}
+	}
+
+	for _, h := range headers {
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return healthResult{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return healthResult{Err: fmt.Errorf("instance answered with status %s", resp.Status)}
+	}
+
+	result := healthResult{Reachable: true, Latency: latency}
+
+	// The version is optional, so a failed read of the page is ignored
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxHealthBodySize))
+	if m := privateBinVersionPattern.FindSubmatch(body); m != nil {
+		result.Version = string(m[1])
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthDataSource_Metadata(t *testing.T) {
+	d := &HealthDataSource{}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "pastebin"}, resp)
+
+	assert.Equal(t, "pastebin_health", resp.TypeName)
+}
+
+func TestHealthDataSource_Schema(t *testing.T) {
+	d := &HealthDataSource{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	for _, attrName := range []string{"host", "reachable", "version", "latency_ms", "error"} {
+		attr, exists := resp.Schema.Attributes[attrName]
+		require.True(t, exists, "Expected attribute %s to be present in schema", attrName)
+		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "platform", r.Header.Get("X-Team"))
+		w.Write([]byte(`<html><script src="js/privatebin.js?1.7.1"></script></html>`))
+	})
+	mux.HandleFunc("/plain/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html></html>`))
+	})
+	mux.HandleFunc("/auth/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/broken/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name              string
+		endpoint          string
+		expectedReachable bool
+		expectedVersion   string
+	}{
+		{
+			name:              "privatebin",
+			endpoint:          server.URL + "/",
+			expectedReachable: true,
+			expectedVersion:   "1.7.1",
+		},
+		{
+			name:              "no version",
+			endpoint:          server.URL + "/plain/",
+			expectedReachable: true,
+		},
+		{
+			name:              "authentication required",
+			endpoint:          server.URL + "/auth/",
+			expectedReachable: true,
+		},
+		{
+			name:     "server error",
+			endpoint: server.URL + "/broken/",
+		},
+		{
+			name:     "connection refused",
+			endpoint: closed.URL,
+		},
+	}
+
+	headers := []headerField{{Name: "X-Team", Value: "platform"}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := url.Parse(tt.endpoint)
+			require.NoError(t, err)
+
+			result := checkHealth(context.Background(), newRedirectClient(http.DefaultTransport), *endpoint, headers)
+
+			assert.Equal(t, tt.expectedReachable, result.Reachable)
+			assert.Equal(t, tt.expectedVersion, result.Version)
+			assert.Equal(t, !tt.expectedReachable, result.Err != nil)
+		})
+	}
+}
+
+func TestHealthDataSource_Read_Unreachable(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	endpoint, err := url.Parse(closed.URL)
+	require.NoError(t, err)
+
+	d := &HealthDataSource{providerData: &ProviderData{Endpoint: *endpoint}}
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	raw := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"host":       tftypes.NewValue(tftypes.String, nil),
+		"reachable":  tftypes.NewValue(tftypes.Bool, nil),
+		"version":    tftypes.NewValue(tftypes.String, nil),
+		"latency_ms": tftypes.NewValue(tftypes.Number, nil),
+		"error":      tftypes.NewValue(tftypes.String, nil),
+	})
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw}}
+
+	d.Read(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+
+	var data HealthDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	assert.Equal(t, closed.URL, data.Host.ValueString())
+	assert.False(t, data.Reachable.ValueBool())
+	assert.True(t, data.LatencyMs.IsNull())
+	assert.NotEmpty(t, data.Error.ValueString())
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// netrcEntry holds the credentials of a netrc machine or default entry.
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+// netrcPath returns the netrc file location, honouring the NETRC
+// environment variable like curl does.
+func netrcPath() (string, error) {
+	if name := os.Getenv("NETRC"); name != "" {
+		return name, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".netrc"), nil
+}
+
+// netrcCredentials returns the login and password of the entry matching host
+// in the netrc file at name, falling back to the default entry. A missing
+// file is treated as having no entries.
+func netrcCredentials(name, host string) (string, string, bool, error) {
+	content, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", "", false, nil
+	}
+	if err != nil {
+		return "", "", false, err
+	}
+
+	machines, defaults, err := parseNetrc(string(content))
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if entry, ok := machines[host]; ok {
+		return entry.login, entry.password, true, nil
+	}
+	if defaults != nil {
+		return defaults.login, defaults.password, true, nil
+	}
+
+	return "", "", false, nil
+}
+
+// parseNetrc parses netrc content into its machine entries and the optional
+// default entry.
+func parseNetrc(content string) (map[string]*netrcEntry, *netrcEntry, error) {
+	// Comments and macro definitions, which run until the next blank line,
+	// are not tokens
+	var lines []string
+	inMacro := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inMacro:
+			inMacro = trimmed != ""
+		case strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "macdef"):
+			inMacro = true
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	machines := make(map[string]*netrcEntry)
+	var defaults, current *netrcEntry
+
+	tokens := strings.Fields(strings.Join(lines, "\n"))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		switch token {
+		case "default":
+			current = &netrcEntry{}
+			defaults = current
+			continue
+		case "machine", "login", "password", "account":
+		default:
+			return nil, nil, fmt.Errorf("netrc: unexpected token %q", token)
+		}
+
+		if i+1 >= len(tokens) {
+			return nil, nil, fmt.Errorf("netrc: %s without a value", token)
+		}
+		i++
+		value := tokens[i]
+
+		if token == "machine" {
+			current = &netrcEntry{}
+			// The first entry for a machine wins
+			if _, ok := machines[value]; !ok {
+				machines[value] = current
+			}
+			continue
+		}
+
+		if current == nil {
+			return nil, nil, fmt.Errorf("netrc: %s before any machine", token)
+		}
+
+		switch token {
+		case "login":
+			current.login = value
+		case "password":
+			current.password = value
+		}
+	}
+
+	return machines, defaults, nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetrcCredentials(t *testing.T) {
+	content := `machine other.example.com login other password otherpass
+
+# Macros are skipped up to the next blank line
+macdef init
+login ignored
+password ignored
+
+machine pastebin.example.com
+  login testuser
+  password testpass
+
+default login anonymous password guest
+`
+	name := filepath.Join(t.TempDir(), ".netrc")
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+
+	tests := []struct {
+		name          string
+		host          string
+		expectedLogin string
+		expectedPass  string
+	}{
+		{
+			name:          "matching machine",
+			host:          "pastebin.example.com",
+			expectedLogin: "testuser",
+			expectedPass:  "testpass",
+		},
+		{
+			name:          "first machine",
+			host:          "other.example.com",
+			expectedLogin: "other",
+			expectedPass:  "otherpass",
+		},
+		{
+			name:          "default entry",
+			host:          "unknown.example.com",
+			expectedLogin: "anonymous",
+			expectedPass:  "guest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login, password, found, err := netrcCredentials(name, tt.host)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tt.expectedLogin, login)
+			assert.Equal(t, tt.expectedPass, password)
+		})
+	}
+}
+
+func TestNetrcCredentials_NoMatch(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".netrc")
+	require.NoError(t, os.WriteFile(name, []byte("machine other.example.com login other password otherpass\n"), 0o600))
+
+	_, _, found, err := netrcCredentials(name, "pastebin.example.com")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestNetrcCredentials_MissingFile(t *testing.T) {
+	_, _, found, err := netrcCredentials(filepath.Join(t.TempDir(), ".netrc"), "pastebin.example.com")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestNetrcCredentials_Malformed(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".netrc")
+	require.NoError(t, os.WriteFile(name, []byte("machine pastebin.example.com login"), 0o600))
+
+	_, _, _, err := netrcCredentials(name, "pastebin.example.com")
+	assert.Error(t, err)
+}
+
+func TestNetrcPath(t *testing.T) {
+	t.Setenv("NETRC", "/tmp/custom-netrc")
+
+	name, err := netrcPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/custom-netrc", name)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &OneTimeLinkFunction{}
+
+var (
+	// pasteIDPattern matches paste IDs, which are made of letters and
+	// digits.
+	pasteIDPattern = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+
+	// masterKeyPattern matches the base58 encoded key of a paste.
+	masterKeyPattern = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]+$`)
+)
+
+func NewOneTimeLinkFunction() function.Function {
+	return &OneTimeLinkFunction{}
+}
+
+// OneTimeLinkFunction defines the function implementation.
+type OneTimeLinkFunction struct{}
+
+func (f *OneTimeLinkFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "one_time_link"
+}
+
+func (f *OneTimeLinkFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the link to share a burn-after-reading paste",
+		MarkdownDescription: "Returns the `https://host/?id#key` link of a paste, for sharing a `burn_after_reading` paste " +
+			"whose first reader deletes it. Pass an empty `master_key` to leave the key out of the link " +
+			"and share it over a separate channel, so the link alone cannot open the paste.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "host",
+				MarkdownDescription: "URL of the instance, such as `https://paste.example.com` or `https://example.com/paste/`",
+			},
+			function.StringParameter{
+				Name:                "paste_id",
+				MarkdownDescription: "ID of the paste",
+			},
+			function.StringParameter{
+				Name:                "master_key",
+				MarkdownDescription: "Key of the paste, such as the `master_key` of a `pastebin_paste` resource, or an empty string to leave it out",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *OneTimeLinkFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var host, pasteID, masterKey string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &host, &pasteID, &masterKey))
+
+	if resp.Error != nil {
+		return
+	}
+
+	hostURL, err := parseLinkHost(host)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid host: "+err.Error())
+		return
+	}
+
+	if !pasteIDPattern.MatchString(pasteID) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid paste ID: expected letters and digits, got: %q", pasteID))
+		return
+	}
+
+	if masterKey != "" && !masterKeyPattern.MatchString(masterKey) {
+		resp.Error = function.NewArgumentFuncError(2, "Invalid master key: expected a base58 encoded key")
+		return
+	}
+
+	hostURL.RawQuery = pasteID
+	hostURL.Fragment = masterKey
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, hostURL.String()))
+}
+
+// parseLinkHost parses the URL of an instance to build paste links on. It
+// must be an absolute http or https URL without a query or fragment.
+func parseLinkHost(host string) (*url.URL, error) {
+	hostURL, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if (hostURL.Scheme != "http" && hostURL.Scheme != "https") || hostURL.Host == "" {
+		return nil, fmt.Errorf("expected an http or https URL like https://paste.example.com, got: %q", host)
+	}
+
+	if hostURL.RawQuery != "" || hostURL.Fragment != "" {
+		return nil, errors.New("the URL must not have a query or fragment")
+	}
+
+	if hostURL.Path == "" {
+		hostURL.Path = "/"
+	}
+
+	return hostURL, nil
+}
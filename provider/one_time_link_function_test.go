@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneTimeLinkFunction_Metadata(t *testing.T) {
+	f := &OneTimeLinkFunction{}
+	resp := &function.MetadataResponse{}
+
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	assert.Equal(t, "one_time_link", resp.Name)
+}
+
+func TestOneTimeLinkFunction_Run(t *testing.T) {
+	const key = "EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF"
+
+	tests := []struct {
+		name             string
+		host             string
+		pasteID          string
+		masterKey        string
+		expected         string
+		expectedArgument int64
+		expectError      bool
+	}{
+		{
+			name:      "with key",
+			host:      "https://paste.example.com",
+			pasteID:   "abcd1234",
+			masterKey: key,
+			expected:  "https://paste.example.com/?abcd1234#" + key,
+		},
+		{
+			name:     "without key",
+			host:     "https://paste.example.com/",
+			pasteID:  "abcd1234",
+			expected: "https://paste.example.com/?abcd1234",
+		},
+		{
+			name:      "instance under a path",
+			host:      "https://example.com/paste/",
+			pasteID:   "abcd1234",
+			masterKey: key,
+			expected:  "https://example.com/paste/?abcd1234#" + key,
+		},
+		{
+			name:        "relative host",
+			host:        "paste.example.com",
+			pasteID:     "abcd1234",
+			expectError: true,
+		},
+		{
+			name:        "host with query",
+			host:        "https://paste.example.com/?other",
+			pasteID:     "abcd1234",
+			expectError: true,
+		},
+		{
+			name:        "unsupported scheme",
+			host:        "ftp://paste.example.com",
+			pasteID:     "abcd1234",
+			expectError: true,
+		},
+		{
+			name:             "empty paste ID",
+			host:             "https://paste.example.com",
+			expectedArgument: 1,
+			expectError:      true,
+		},
+		{
+			name:             "paste ID with URL characters",
+			host:             "https://paste.example.com",
+			pasteID:          "abcd#1234",
+			expectedArgument: 1,
+			expectError:      true,
+		},
+		{
+			name:             "key that is not base58",
+			host:             "https://paste.example.com",
+			pasteID:          "abcd1234",
+			masterKey:        "not/base58=",
+			expectedArgument: 2,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &OneTimeLinkFunction{}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.host),
+					types.StringValue(tt.pasteID),
+					types.StringValue(tt.masterKey),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.expectError {
+				require.NotNil(t, resp.Error)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				assert.Equal(t, tt.expectedArgument, *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.StringValue(tt.expected), resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// attachmentFormatter is the formatter attachments default to. The others
+// render paste text, which an attachment upload does not have.
+const attachmentFormatter = "plaintext"
+
+// attachmentConfig validates the attributes that turn a paste into an
+// attachment against the rest of the configuration.
+func attachmentConfig() resource.ConfigValidator {
+	return attachmentConfigValidator{}
+}
+
+type attachmentConfigValidator struct{}
+
+func (v attachmentConfigValidator) Description(ctx context.Context) string {
+	return "Checks that attachment_name is not empty, and warns when it is combined with a text formatter."
+}
+
+func (v attachmentConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return "Checks that `attachment_name` is not empty, and warns when it is combined with a text formatter."
+}
+
+func (v attachmentConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var attachmentName, contentDir, formatter types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attachment_name"), &attachmentName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_dir"), &contentDir)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("formatter"), &formatter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A content_dir archive is an attachment even without a name
+	if attachmentName.IsNull() && !contentDir.IsNull() && !contentDir.IsUnknown() {
+		attachmentName = types.StringValue(contentDirAttachmentName(contentDir.ValueString()))
+	}
+
+	if attachmentName.IsNull() || attachmentName.IsUnknown() {
+		return
+	}
+
+	// An empty name would silently upload the content as paste text
+	if attachmentName.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("attachment_name"),
+			"Empty Attachment Name",
+			"attachment_name must not be empty. Set it to the file name of the attachment, "+
+				"or remove it to share the content as paste text.",
+		)
+		return
+	}
+
+	// Left unset, the formatter defaults to plaintext
+	if formatter.IsNull() || formatter.IsUnknown() || formatter.ValueString() == attachmentFormatter {
+		return
+	}
+
+	// Pastes created this way before still apply, so only warn
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("formatter"),
+		"Formatter Conflicts With Attachment",
+		fmt.Sprintf("The %q formatter renders paste text, but attachment_name %q uploads the content as an attachment, "+
+			"which is shown as is. Remove formatter or set it to %q.",
+			formatter.ValueString(), attachmentName.ValueString(), attachmentFormatter),
+	)
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasteResource_ConfigValidators(t *testing.T) {
+	r := &PasteResource{}
+	validators := r.ConfigValidators(context.Background())
+
+	require.Len(t, validators, 1)
+	assert.IsType(t, attachmentConfigValidator{}, validators[0])
+}
+
+func TestAttachmentConfigValidator(t *testing.T) {
+	tests := []struct {
+		name           string
+		attachmentName tftypes.Value
+		contentDir     tftypes.Value
+		formatter      tftypes.Value
+		expectedPath   string
+		expectWarning  bool
+	}{
+		{
+			name:           "no attachment",
+			attachmentName: tftypes.NewValue(tftypes.String, nil),
+			formatter:      tftypes.NewValue(tftypes.String, "markdown"),
+		},
+		{
+			name:           "attachment without formatter",
+			attachmentName: tftypes.NewValue(tftypes.String, "report.pdf"),
+			formatter:      tftypes.NewValue(tftypes.String, nil),
+		},
+		{
+			name:           "attachment with plaintext",
+			attachmentName: tftypes.NewValue(tftypes.String, "report.pdf"),
+			formatter:      tftypes.NewValue(tftypes.String, "plaintext"),
+		},
+		{
+			name:           "attachment with unknown formatter",
+			attachmentName: tftypes.NewValue(tftypes.String, "report.pdf"),
+			formatter:      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+		{
+			name:           "unknown attachment with markdown",
+			attachmentName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			formatter:      tftypes.NewValue(tftypes.String, "markdown"),
+		},
+		{
+			name:           "attachment with markdown",
+			attachmentName: tftypes.NewValue(tftypes.String, "report.pdf"),
+			formatter:      tftypes.NewValue(tftypes.String, "markdown"),
+			expectedPath:   "formatter",
+			expectWarning:  true,
+		},
+		{
+			name:           "attachment with syntax highlighting",
+			attachmentName: tftypes.NewValue(tftypes.String, "main.go"),
+			formatter:      tftypes.NewValue(tftypes.String, "syntaxhighlighting"),
+			expectedPath:   "formatter",
+			expectWarning:  true,
+		},
+		{
+			name:           "empty attachment name",
+			attachmentName: tftypes.NewValue(tftypes.String, ""),
+			formatter:      tftypes.NewValue(tftypes.String, nil),
+			expectedPath:   "attachment_name",
+		},
+		{
+			name:           "content directory with markdown",
+			attachmentName: tftypes.NewValue(tftypes.String, nil),
+			contentDir:     tftypes.NewValue(tftypes.String, "configs"),
+			formatter:      tftypes.NewValue(tftypes.String, "markdown"),
+			expectedPath:   "formatter",
+			expectWarning:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"content":         tftypes.NewValue(tftypes.String, "data"),
+				"attachment_name": tt.attachmentName,
+				"formatter":       tt.formatter,
+			}
+			if !tt.contentDir.IsNull() {
+				values["content"] = tftypes.NewValue(tftypes.String, nil)
+				values["content_dir"] = tt.contentDir
+			}
+			req := resource.ValidateConfigRequest{
+				Config: testResourceConfig(t, &PasteResource{}, values),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			attachmentConfig().ValidateResource(context.Background(), req, resp)
+
+			if tt.expectedPath == "" {
+				assert.Empty(t, resp.Diagnostics)
+				return
+			}
+
+			diags := resp.Diagnostics.Errors()
+			if tt.expectWarning {
+				assert.False(t, resp.Diagnostics.HasError())
+				diags = resp.Diagnostics.Warnings()
+			}
+
+			require.Len(t, diags, 1)
+			diagWithPath, ok := diags[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, path.Root(tt.expectedPath), diagWithPath.Path())
+		})
+	}
+}
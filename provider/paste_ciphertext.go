@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// maxCiphertextSize bounds the size of the encrypted paste fetched with
+// decrypt = false, leaving room for the base64 and JSON overhead over the
+// default size limit of PrivateBin instances.
+const maxCiphertextSize = 2 * maxContentURLSize
+
+// encryptedPaste is a paste as the instance stores it. Together with the
+// master key, and the password if any, it is all that is needed to
+// decrypt the paste later.
+type encryptedPaste struct {
+	Version int             `json:"v"`
+	AData   json.RawMessage `json:"adata"`
+	CT      string          `json:"ct"`
+	Meta    json.RawMessage `json:"meta,omitempty"`
+}
+
+// ciphertextResult is the outcome of fetchCiphertext.
+type ciphertextResult struct {
+	PasteID      string
+	CommentCount int
+
+	// Document is the JSON encoded encryptedPaste.
+	Document []byte
+}
+
+// fetchCiphertext requests the paste at pasteURL from the JSON API of the
+// instance without decrypting it, so the master key is not needed. The
+// provider headers are sent, and basic authentication when username is
+// set.
+func fetchCiphertext(ctx context.Context, client *http.Client, pasteURL url.URL, headers []headerField, username, password string) (*ciphertextResult, error) {
+	// The key never leaves the client
+	pasteURL.Fragment = ""
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pasteURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range headers {
+		req.Header.Add(h.Name, h.Value)
+	}
+	req.Header.Set("X-Requested-With", "JSONHttpRequest")
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		encryptedPaste
+		Status       int    `json:"status"`
+		Message      string `json:"message"`
+		ID           string `json:"id"`
+		CommentCount int    `json:"comment_count"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCiphertextSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("unable to decode the paste: %w", err)
+	}
+
+	if body.Status != 0 {
+		return nil, fmt.Errorf("the instance returned an error: %s", body.Message)
+	}
+
+	if body.CT == "" || len(body.AData) == 0 {
+		return nil, errors.New("the instance returned no ciphertext")
+	}
+
+	document, err := json.Marshal(body.encryptedPaste)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ciphertextResult{
+		PasteID:      body.ID,
+		CommentCount: body.CommentCount,
+		Document:     document,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+func TestPasteDataSource_Read_WithoutDecrypting(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	providerData := fake.providerData(t, map[string]tftypes.Value{
+		"username": tftypes.NewValue(tftypes.String, "user"),
+		"password": tftypes.NewValue(tftypes.String, "hunter2"),
+	})
+	ctx := context.Background()
+
+	created, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), pastebin.CreatePasteOptions{
+		Formatter: "markdown",
+		Expire:    "1day",
+		Compress:  pastebin.CompressionAlgorithmGZip,
+		Password:  []byte("secret"),
+	})
+	require.NoError(t, err)
+
+	// Neither the key nor the password is needed
+	pasteURL := created.PasteURL
+	pasteURL.Fragment = ""
+
+	d := &PasteDataSource{providerData: providerData}
+	config := testDataSourceConfig(t, d, map[string]tftypes.Value{
+		"url":     tftypes.NewValue(tftypes.String, pasteURL.String()),
+		"decrypt": tftypes.NewValue(tftypes.Bool, false),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data PasteDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	assert.Equal(t, created.PasteID, data.ID.ValueString())
+	assert.True(t, data.Content.IsNull())
+	assert.True(t, data.PasswordProtect.IsNull())
+	assert.Equal(t, int64(0), data.CommentCount.ValueInt64())
+
+	document, err := base64.StdEncoding.DecodeString(data.RawCiphertext.ValueString())
+	require.NoError(t, err)
+
+	var paste encryptedPaste
+	require.NoError(t, json.Unmarshal(document, &paste))
+	assert.Equal(t, 2, paste.Version)
+	assert.NotEmpty(t, paste.CT)
+	assert.NotContains(t, string(document), "Hello, World!")
+
+	// The stored ciphertext is returned as is, and the provider credentials
+	// were sent
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	for id, stored := range fake.pastes {
+		assert.Equal(t, created.PasteID, id)
+		assert.Equal(t, stored.CT, paste.CT)
+		assert.JSONEq(t, string(stored.AData), string(paste.AData))
+	}
+	last := fake.requests[len(fake.requests)-1]
+	assert.Equal(t, http.MethodGet, last.Method)
+	assert.Equal(t, "Basic dXNlcjpodW50ZXIy", last.Header.Get("Authorization"))
+}
+
+func TestFetchCiphertext_Missing(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	pasteURL, err := url.Parse(fake.URL + "/?ffffffffffffffff")
+	require.NoError(t, err)
+
+	_, err = fetchCiphertext(context.Background(), http.DefaultClient, *pasteURL, nil, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Paste does not exist")
+	assert.False(t, isTransportError(err))
+}
+
+func TestPasteDataSource_ValidateConfig_DecryptRequired(t *testing.T) {
+	d := &PasteDataSource{}
+	req := datasource.ValidateConfigRequest{
+		Config: testDataSourceConfig(t, d, map[string]tftypes.Value{
+			"url":             tftypes.NewValue(tftypes.String, "https://example.com/?abc"),
+			"decrypt":         tftypes.NewValue(tftypes.Bool, false),
+			"expected_sha256": tftypes.NewValue(tftypes.String, contentSHA256([]byte("data"))),
+		}),
+	}
+	resp := &datasource.ValidateConfigResponse{}
+
+	d.ValidateConfig(context.Background(), req, resp)
+
+	require.Len(t, resp.Diagnostics.Errors(), 1)
+	assert.Equal(t, "Decryption Required", resp.Diagnostics.Errors()[0].Summary())
+	diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	assert.Equal(t, path.Root("expected_sha256"), diagWithPath.Path())
+}
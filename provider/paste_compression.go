@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+// compressedMIMETypes lists media types, outside of image, audio and video,
+// whose data is already compressed.
+var compressedMIMETypes = map[string]bool{
+	"application/gzip":             true,
+	"application/vnd.rar":          true,
+	"application/x-7z-compressed":  true,
+	"application/x-bzip":           true,
+	"application/x-bzip2":          true,
+	"application/x-compressed-tar": true,
+	"application/x-gzip":           true,
+	"application/x-rar-compressed": true,
+	"application/x-xz":             true,
+	"application/zip":              true,
+	"application/zstd":             true,
+	"font/woff2":                   true,
+}
+
+// uncompressedMIMETypes lists image, audio and video media types whose data
+// is usually stored uncompressed.
+var uncompressedMIMETypes = map[string]bool{
+	"audio/wav":     true,
+	"audio/wave":    true,
+	"audio/x-wav":   true,
+	"image/bmp":     true,
+	"image/svg+xml": true,
+	"image/x-icon":  true,
+}
+
+// attachmentMIMEType returns the media type of an attachment, taken from
+// its file extension or, failing that, sniffed from its content.
+func attachmentMIMEType(name string, content []byte) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(name))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(content)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return mimeType
+	}
+
+	return mediaType
+}
+
+// isCompressedMIMEType reports whether data of the media type is already
+// compressed, so gzipping it again gains nothing.
+func isCompressedMIMEType(mediaType string) bool {
+	if compressedMIMETypes[mediaType] {
+		return true
+	}
+
+	if uncompressedMIMETypes[mediaType] {
+		return false
+	}
+
+	for _, prefix := range []string{"image/", "audio/", "video/"} {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skipGZipForCompressedAttachment plans gzip as false for new attachments
+// that are already compressed, unless gzip is set in the configuration.
+// An unknown plan is still resolved from the provider default afterwards.
+func skipGZipForCompressedAttachment() planmodifier.Bool {
+	return skipGZipForCompressedAttachmentModifier{}
+}
+
+type skipGZipForCompressedAttachmentModifier struct{}
+
+func (m skipGZipForCompressedAttachmentModifier) Description(ctx context.Context) string {
+	return "Disables gzip by default for attachments that are already compressed."
+}
+
+func (m skipGZipForCompressedAttachmentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m skipGZipForCompressedAttachmentModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// An explicit gzip setting always wins
+	if !req.ConfigValue.IsNull() || (!req.PlanValue.IsUnknown() && !req.PlanValue.ValueBool()) {
+		return
+	}
+
+	// Only new pastes get the heuristic. Existing pastes keep the stored
+	// gzip, since changing it would replace them.
+	if !req.StateValue.IsNull() {
+		return
+	}
+
+	var data PasteResourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attachment_name"), &data.AttachmentName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &data.Content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_base64"), &data.ContentBase64)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_template"), &data.ContentTemplate)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("template_vars"), &data.TemplateVars)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_dir"), &data.ContentDir)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_url"), &data.ContentURL)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// content_url is only fetched when the paste is created
+	attachmentName := pasteAttachmentName(data)
+	if attachmentName == "" || data.AttachmentName.IsUnknown() || !pasteContentKnown(data) || !data.ContentURL.IsNull() {
+		return
+	}
+
+	// Invalid base64, templates and directories are reported by
+	// ValidateConfig
+	content, err := pasteContent(data)
+	if err != nil {
+		return
+	}
+
+	mediaType := attachmentMIMEType(attachmentName, content)
+	if !isCompressedMIMEType(mediaType) {
+		return
+	}
+
+	tflog.Debug(ctx, "attachment is already compressed, disabling gzip", map[string]interface{}{
+		"attachment_name": attachmentName,
+		"mime_type":       mediaType,
+	})
+
+	resp.PlanValue = types.BoolValue(false)
+}
+
+// compressionRejected is the message PrivateBin returns when it rejects
+// the parameters of a paste, such as a compression it does not support.
+// The paste is not stored, so creating it again cannot leave a duplicate.
+const compressionRejected = "Invalid data."
+
+// isCompressionRejectedError reports whether err was caused by the
+// instance rejecting a compressed paste. Other errors, such as failing to
+// decode the response, may follow a paste that was created, so they never
+// match.
+func isCompressionRejectedError(err error) bool {
+	return !isTransportError(err) && strings.Contains(err.Error(), compressionRejected)
+}
+
+// retryUncompressed reports whether a paste whose creation with options
+// failed with err should be created once more without compression, which
+// is the case when GZipFallback is set and the instance rejected the
+// compressed paste. The secrets are redacted from the logged error.
+func (p *ProviderData) retryUncompressed(ctx context.Context, options pastebin.CreatePasteOptions, err error, secrets ...string) bool {
+	if !p.GZipFallback || options.Compress == pastebin.CompressionAlgorithmNone || !isCompressionRejectedError(err) {
+		return false
+	}
+
+	tflog.Warn(ctx, "instance rejected the compressed paste, retrying without compression", map[string]interface{}{
+		"error": p.redactError(err, secrets...),
+	})
+
+	return true
+}
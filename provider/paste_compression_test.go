@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+func TestAttachmentMIMEType(t *testing.T) {
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	_, err := w.Write([]byte("Hello, World!"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "image/png", attachmentMIMEType("logo.png", nil))
+	assert.Equal(t, "application/x-gzip", attachmentMIMEType("backup", gzipped.Bytes()))
+	assert.Equal(t, "text/plain", attachmentMIMEType("notes", []byte("Hello, World!")))
+}
+
+func TestIsCompressedMIMEType(t *testing.T) {
+	for _, mediaType := range []string{"application/zip", "application/x-gzip", "image/jpeg", "video/mp4", "audio/mpeg"} {
+		assert.True(t, isCompressedMIMEType(mediaType), "expected %s to be compressed", mediaType)
+	}
+
+	for _, mediaType := range []string{"text/plain", "application/json", "image/svg+xml", "audio/wav", "application/octet-stream"} {
+		assert.False(t, isCompressedMIMEType(mediaType), "expected %s not to be compressed", mediaType)
+	}
+}
+
+func TestSkipGZipForCompressedAttachment(t *testing.T) {
+	tests := []struct {
+		name           string
+		attachmentName tftypes.Value
+		gzip           tftypes.Value
+		expected       bool
+	}{
+		{
+			name:           "compressed attachment with default gzip",
+			attachmentName: tftypes.NewValue(tftypes.String, "photo.jpg"),
+			gzip:           tftypes.NewValue(tftypes.Bool, nil),
+			expected:       false,
+		},
+		{
+			name:           "compressed attachment with explicit gzip",
+			attachmentName: tftypes.NewValue(tftypes.String, "photo.jpg"),
+			gzip:           tftypes.NewValue(tftypes.Bool, true),
+			expected:       true,
+		},
+		{
+			name:           "text attachment",
+			attachmentName: tftypes.NewValue(tftypes.String, "notes.txt"),
+			gzip:           tftypes.NewValue(tftypes.Bool, nil),
+			expected:       true,
+		},
+		{
+			name:           "no attachment",
+			attachmentName: tftypes.NewValue(tftypes.String, nil),
+			gzip:           tftypes.NewValue(tftypes.Bool, nil),
+			expected:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &PasteResource{}
+
+			config := testResourceConfig(t, r, map[string]tftypes.Value{
+				"content":         tftypes.NewValue(tftypes.String, "content"),
+				"attachment_name": tt.attachmentName,
+				"gzip":            tt.gzip,
+			})
+			var configValue types.Bool
+			require.False(t, config.GetAttribute(ctx, path.Root("gzip"), &configValue).HasError())
+
+			req := planmodifier.BoolRequest{
+				Path:        path.Root("gzip"),
+				Config:      config,
+				ConfigValue: configValue,
+				PlanValue:   types.BoolValue(true),
+			}
+			resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+
+			skipGZipForCompressedAttachment().PlanModifyBool(ctx, req, resp)
+
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tt.expected, resp.PlanValue.ValueBool())
+		})
+	}
+}
+
+func TestSkipGZipForCompressedAttachment_ExistingPaste(t *testing.T) {
+	ctx := context.Background()
+	r := &PasteResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	gzipAttribute, ok := schemaResp.Schema.Attributes["gzip"].(schema.BoolAttribute)
+	require.True(t, ok)
+
+	// A paste created before the heuristic, with the old default
+	config := testResourceConfig(t, r, map[string]tftypes.Value{
+		"content":         tftypes.NewValue(tftypes.String, "content"),
+		"attachment_name": tftypes.NewValue(tftypes.String, "photo.jpg"),
+	})
+	stored := testResourceConfig(t, r, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "abc123"),
+		"content":         tftypes.NewValue(tftypes.String, "content"),
+		"attachment_name": tftypes.NewValue(tftypes.String, "photo.jpg"),
+		"gzip":            tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	req := planmodifier.BoolRequest{
+		Path:        path.Root("gzip"),
+		Config:      config,
+		ConfigValue: types.BoolNull(),
+		Plan:        tfsdk.Plan{Schema: stored.Schema, Raw: stored.Raw},
+		PlanValue:   types.BoolUnknown(),
+		State:       tfsdk.State{Schema: stored.Schema, Raw: stored.Raw},
+		StateValue:  types.BoolValue(true),
+	}
+	resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+
+	// Run the modifiers of the schema in order, as Terraform does
+	for _, modifier := range gzipAttribute.PlanModifiers {
+		req.PlanValue = resp.PlanValue
+		modifier.PlanModifyBool(ctx, req, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	}
+
+	assert.True(t, resp.PlanValue.ValueBool())
+	assert.False(t, resp.RequiresReplace)
+}
+
+func TestProviderData_RetryUncompressed(t *testing.T) {
+	rejected := errors.New("server error: Invalid data.")
+	unreachable := &url.Error{Op: "Post", URL: "https://example.com", Err: errors.New("connection refused")}
+	compressed := pastebin.CreatePasteOptions{Compress: pastebin.CompressionAlgorithmGZip}
+	uncompressed := pastebin.CreatePasteOptions{Compress: pastebin.CompressionAlgorithmNone}
+
+	tests := []struct {
+		name     string
+		fallback bool
+		options  pastebin.CreatePasteOptions
+		err      error
+		expected bool
+	}{
+		{"rejected compressed paste", true, compressed, rejected, true},
+		{"fallback disabled", false, compressed, rejected, false},
+		{"already uncompressed", true, uncompressed, rejected, false},
+		{"unrelated error", true, compressed, errors.New("invalid expire"), false},
+		{"response not decoded", true, compressed, errors.New("cannot decode response: invalid character '<' looking for beginning of value"), false},
+		{"compression mentioned", true, compressed, errors.New("failed to inflate the paste: zlib: invalid header"), false},
+		{"transport error", true, compressed, unreachable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ProviderData{GZipFallback: tt.fallback}
+			assert.Equal(t, tt.expected, p.retryUncompressed(context.Background(), tt.options, tt.err))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// maxContentDirSize bounds the size of the archive built from content_dir,
+// matching the default size limit of PrivateBin instances.
+const maxContentDirSize = 10 << 20
+
+// errContentDirTooLarge is returned once the archive of a content_dir
+// exceeds maxContentDirSize.
+var errContentDirTooLarge = fmt.Errorf("archive exceeds %d bytes", maxContentDirSize)
+
+// limitedBuffer is a buffer that refuses to grow beyond limit bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errContentDirTooLarge
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// contentDirAttachmentName returns the attachment name of the archive of
+// dir, such as "configs.tar.gz" for the directory ./configs.
+func contentDirAttachmentName(dir string) string {
+	name := filepath.Base(filepath.Clean(dir))
+	if name == "." || name == string(filepath.Separator) {
+		name = "content"
+	}
+
+	return name + ".tar.gz"
+}
+
+// archiveContentDir returns the files below dir as a gzipped tarball. The
+// archive only depends on the names, modes and contents of the files, not
+// on their times or owners, so an unchanged directory yields the same
+// archive on every plan. Only directories and regular files are archived.
+func archiveContentDir(dir string) ([]byte, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	archive := &limitedBuffer{limit: maxContentDirSize}
+	gz := gzip.NewWriter(archive)
+	tw := tar.NewWriter(gz)
+
+	files := 0
+	// WalkDir visits entries in lexical order, which keeps the archive
+	// stable
+	err = filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		header := &tar.Header{
+			Name: filepath.ToSlash(rel),
+			Mode: int64(info.Mode().Perm()),
+		}
+
+		switch {
+		case info.IsDir():
+			header.Typeflag = tar.TypeDir
+			header.Name += "/"
+		case info.Mode().IsRegular():
+			header.Typeflag = tar.TypeReg
+			header.Size = info.Size()
+		default:
+			return fmt.Errorf("%s is not a regular file or directory", name)
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeDir {
+			return nil
+		}
+
+		files++
+
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if files == 0 {
+		return nil, fmt.Errorf("%s contains no files", dir)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return archive.Bytes(), nil
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeContentDir creates a directory holding files, keyed by their slash
+// separated path, and returns its path.
+func writeContentDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "configs")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+// readArchive returns the regular files in a gzipped tarball by name.
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		if header.Typeflag == tar.TypeReg {
+			content, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[header.Name] = string(content)
+		}
+	}
+
+	return files
+}
+
+func TestArchiveContentDir(t *testing.T) {
+	files := map[string]string{
+		"app.yaml":         "replicas: 3",
+		"nested/db.yaml":   "host: db",
+		"nested/empty.txt": "",
+	}
+	dir := writeContentDir(t, files)
+
+	archive, err := archiveContentDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, files, readArchive(t, archive))
+
+	// Times do not change the archive
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "app.yaml"), later, later))
+
+	again, err := archiveContentDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, contentSHA256(archive), contentSHA256(again))
+}
+
+func TestArchiveContentDir_Errors(t *testing.T) {
+	emptyDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(emptyDir, "nested"), 0o755))
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+
+	// Random data does not compress below the limit
+	large := make([]byte, maxContentDirSize+1)
+	_, err := rand.Read(large)
+	require.NoError(t, err)
+	largeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(largeDir, "large.bin"), large, 0o600))
+
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{"missing", filepath.Join(t.TempDir(), "missing")},
+		{"not a directory", file},
+		{"no files", emptyDir},
+		{"too large", largeDir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := archiveContentDir(tt.dir)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestContentDirAttachmentName(t *testing.T) {
+	assert.Equal(t, "configs.tar.gz", contentDirAttachmentName("./configs/"))
+	assert.Equal(t, "configs.tar.gz", contentDirAttachmentName(filepath.Join("deploy", "configs")))
+	assert.Equal(t, "content.tar.gz", contentDirAttachmentName("."))
+}
+
+func TestPasteAttachmentName(t *testing.T) {
+	assert.Equal(t, "", pasteAttachmentName(PasteResourceModel{
+		Content: types.StringValue("data"),
+	}))
+	assert.Equal(t, "configs.tar.gz", pasteAttachmentName(PasteResourceModel{
+		ContentDir: types.StringValue("configs"),
+	}))
+	assert.Equal(t, "bundle.tgz", pasteAttachmentName(PasteResourceModel{
+		ContentDir:     types.StringValue("configs"),
+		AttachmentName: types.StringValue("bundle.tgz"),
+	}))
+}
+
+func TestPasteResource_ValidateConfig_ContentDir(t *testing.T) {
+	tests := []struct {
+		name         string
+		dir          string
+		expectedPath string
+	}{
+		{
+			name: "archived",
+			dir:  writeContentDir(t, map[string]string{"app.yaml": "replicas: 3"}),
+		},
+		{
+			name:         "empty directory",
+			dir:          t.TempDir(),
+			expectedPath: "content_dir",
+		},
+		{
+			name:         "missing directory",
+			dir:          filepath.Join(t.TempDir(), "missing"),
+			expectedPath: "content_dir",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PasteResource{}
+			req := resource.ValidateConfigRequest{
+				Config: testResourceConfig(t, r, map[string]tftypes.Value{
+					"content_dir": tftypes.NewValue(tftypes.String, tt.dir),
+				}),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			r.ValidateConfig(context.Background(), req, resp)
+
+			if tt.expectedPath == "" {
+				assert.False(t, resp.Diagnostics.HasError())
+				return
+			}
+
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, path.Root(tt.expectedPath), diagWithPath.Path())
+		})
+	}
+}
+
+func TestPasteResource_ModifyPlan_ContentDirChanged(t *testing.T) {
+	ctx := context.Background()
+	r := &PasteResource{}
+	dir := writeContentDir(t, map[string]string{"app.yaml": "replicas: 3"})
+
+	archive, err := archiveContentDir(dir)
+	require.NoError(t, err)
+
+	state := testResourceConfig(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content_dir":    tftypes.NewValue(tftypes.String, dir),
+		"content_sha256": tftypes.NewValue(tftypes.String, contentSHA256(archive)),
+	})
+
+	modifyPlan := func() *resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+			Plan:  tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+		require.False(t, resp.Diagnostics.HasError())
+		return resp
+	}
+
+	assert.Empty(t, modifyPlan().RequiresReplace)
+
+	// Only the files change, not the configuration
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte("replicas: 5"), 0o644))
+
+	resp := modifyPlan()
+	assert.Equal(t, path.Paths{path.Root("content_dir")}, resp.RequiresReplace)
+
+	changed, err := archiveContentDir(dir)
+	require.NoError(t, err)
+	var sha types.String
+	require.False(t, resp.Plan.GetAttribute(ctx, path.Root("content_sha256"), &sha).HasError())
+	assert.Equal(t, contentSHA256(changed), sha.ValueString())
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// contentEncoding returns the character encoding with the given name or
+// label, such as utf-8, latin1 or shift_jis. Labels follow the WHATWG
+// Encoding Standard used by browsers, so latin1 is windows-1252.
+func contentEncoding(name string) (encoding.Encoding, error) {
+	return htmlindex.Get(name)
+}
+
+// decodeContent converts content from the named encoding to UTF-8. Without
+// a name the content is assumed to be UTF-8 already and returned as is.
+func decodeContent(content []byte, name string) ([]byte, error) {
+	if name == "" {
+		return content, nil
+	}
+
+	enc, err := contentEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if enc == unicode.UTF8 {
+		return content, nil
+	}
+
+	return enc.NewDecoder().Bytes(content)
+}
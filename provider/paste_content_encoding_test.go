@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		encoding string
+		expected string
+	}{
+		{"unset", []byte("café"), "", "café"},
+		{"utf-8", []byte("café"), "UTF-8", "café"},
+		{"latin1", []byte{'c', 'a', 'f', 0xe9}, "latin1", "café"},
+		{"windows-1252 only characters", []byte{0x80, ' ', 0x93, 'q', 0x94}, "iso-8859-1", "€ “q”"},
+		{"shift_jis", []byte{0x93, 0xfa, 0x96, 0x7b}, "shift_jis", "日本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeContent(tt.content, tt.encoding)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(decoded))
+		})
+	}
+
+	_, err := decodeContent([]byte("data"), "klingon")
+	assert.Error(t, err)
+}
+
+func TestPasteDataSource_ValidateConfig_ContentEncoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		encoding    tftypes.Value
+		expectError bool
+	}{
+		{"unset", tftypes.NewValue(tftypes.String, nil), false},
+		{"known", tftypes.NewValue(tftypes.String, "latin1"), false},
+		{"unknown value", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), false},
+		{"unknown encoding", tftypes.NewValue(tftypes.String, "klingon"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &PasteDataSource{}
+			req := datasource.ValidateConfigRequest{
+				Config: testDataSourceConfig(t, d, map[string]tftypes.Value{
+					"url":              tftypes.NewValue(tftypes.String, "https://example.com/?abc#key"),
+					"content_encoding": tt.encoding,
+				}),
+			}
+			resp := &datasource.ValidateConfigResponse{}
+
+			d.ValidateConfig(context.Background(), req, resp)
+
+			if !tt.expectError {
+				assert.False(t, resp.Diagnostics.HasError())
+				return
+			}
+
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, path.Root("content_encoding"), diagWithPath.Path())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// maxContentURLSize bounds the size of the content fetched from
+// content_url, matching the default size limit of PrivateBin instances.
+const maxContentURLSize = 10 << 20
+
+// contentURLTimeout bounds how long fetching content_url may take,
+// including redirects and reading the body.
+const contentURLTimeout = 30 * time.Second
+
+// contentURLPattern matches the http and https URLs content_url accepts.
+var contentURLPattern = regexp.MustCompile(`^(?i)https?://[^/?#]+`)
+
+// errContentURLTooLarge is returned when the content at content_url
+// exceeds maxContentURLSize.
+var errContentURLTooLarge = fmt.Errorf("content exceeds %d bytes", maxContentURLSize)
+
+// fetchContentURL returns the body of a GET request to rawURL. Redirects
+// are followed as for paste URLs, and anything but a 2xx response is an
+// error.
+func fetchContentURL(ctx context.Context, transport http.RoundTripper, rawURL string) ([]byte, error) {
+	client := newRedirectClient(transport)
+	client.Timeout = contentURLTimeout
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	// Read one byte past the limit to tell a body of exactly the maximum
+	// size from a larger one
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxContentURLSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxContentURLSize {
+		return nil, errContentURLTooLarge
+	}
+
+	return content, nil
+}
+
+// fetchPasteContent returns the content of the paste like pasteContent,
+// fetching it when content_url is set.
+func (r *PasteResource) fetchPasteContent(ctx context.Context, data PasteResourceModel) ([]byte, error) {
+	if data.ContentURL.IsNull() {
+		return pasteContent(data)
+	}
+
+	transport := http.DefaultTransport
+	if r.providerData != nil {
+		transport = r.providerData.httpTransport()
+	}
+
+	return fetchContentURL(ctx, transport, data.ContentURL.ValueString())
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchContentURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config.yaml":
+			w.Write([]byte("replicas: 3"))
+		case "/moved":
+			http.Redirect(w, r, "/config.yaml", http.StatusFound)
+		case "/large":
+			w.Write([]byte(strings.Repeat("a", maxContentURLSize+1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "fetched", path: "/config.yaml", expected: "replicas: 3"},
+		{name: "redirected", path: "/moved", expected: "replicas: 3"},
+		{name: "not found", path: "/missing", wantErr: true},
+		{name: "too large", path: "/large", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := fetchContentURL(context.Background(), http.DefaultTransport, server.URL+tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestContentURLPattern(t *testing.T) {
+	assert.True(t, contentURLPattern.MatchString("https://example.com/config.yaml"))
+	assert.True(t, contentURLPattern.MatchString("HTTP://example.com"))
+	assert.False(t, contentURLPattern.MatchString("file:///etc/passwd"))
+	assert.False(t, contentURLPattern.MatchString("https://"))
+	assert.False(t, contentURLPattern.MatchString("example.com/config.yaml"))
+}
+
+func TestPasteResource_ModifyPlan_ContentURLChanged(t *testing.T) {
+	ctx := context.Background()
+	r := &PasteResource{}
+
+	var content atomic.Value
+	content.Store("replicas: 3")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(content.Load().(string)))
+	}))
+	defer server.Close()
+
+	state := testResourceConfig(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "abcd1234"),
+		"content_url":    tftypes.NewValue(tftypes.String, server.URL),
+		"content_sha256": tftypes.NewValue(tftypes.String, contentSHA256([]byte("replicas: 3"))),
+	})
+
+	modifyPlan := func() *resource.ModifyPlanResponse {
+		req := resource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+			Plan:  tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+		require.False(t, resp.Diagnostics.HasError())
+		return resp
+	}
+
+	assert.Empty(t, modifyPlan().RequiresReplace)
+
+	// Only the remote content changes, not the configuration
+	content.Store("replicas: 5")
+
+	resp := modifyPlan()
+	assert.Equal(t, path.Paths{path.Root("content_url")}, resp.RequiresReplace)
+
+	var sha types.String
+	require.False(t, resp.Plan.GetAttribute(ctx, path.Root("content_sha256"), &sha).HasError())
+	assert.Equal(t, contentSHA256([]byte("replicas: 5")), sha.ValueString())
+
+	// An unreachable URL keeps the paste
+	server.Close()
+	assert.Empty(t, modifyPlan().RequiresReplace)
+}
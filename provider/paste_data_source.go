@@ -1,20 +1,40 @@
 package provider
 
 import (
+	"bufio"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/RO-29/pastebin-go-cli"
 )
 
+// base64ChunkSize is the buffer size used when streaming attachments.
+const base64ChunkSize = 64 * 1024
+
+// sha256Pattern matches a hex encoded SHA-256 checksum.
+var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &PasteDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &PasteDataSource{}
 
 func NewPasteDataSource() datasource.DataSource {
 	return &PasteDataSource{}
@@ -27,15 +47,35 @@ type PasteDataSource struct {
 
 // PasteDataSourceModel describes the data source data model.
 type PasteDataSourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	URL            types.String `tfsdk:"url"`
-	Password       types.String `tfsdk:"password"`
-	ConfirmBurn    types.Bool   `tfsdk:"confirm_burn"`
-	Content        types.String `tfsdk:"content"`
-	AttachmentName types.String `tfsdk:"attachment_name"`
-	AttachmentData types.String `tfsdk:"attachment_data"`
-	MimeType       types.String `tfsdk:"mime_type"`
-	CommentCount   types.Int64  `tfsdk:"comment_count"`
+	ID               types.String   `tfsdk:"id"`
+	URL              types.String   `tfsdk:"url"`
+	PasteID          types.String   `tfsdk:"paste_id"`
+	MasterKey        types.String   `tfsdk:"master_key"`
+	Password         types.String   `tfsdk:"password"`
+	BasicAuthUser    types.String   `tfsdk:"basic_auth_username"`
+	BasicAuthPass    types.String   `tfsdk:"basic_auth_password"`
+	ConfirmBurn      types.Bool     `tfsdk:"confirm_burn"`
+	ConfirmDelete    types.Bool     `tfsdk:"i_understand_this_deletes_the_paste"`
+	Content          types.String   `tfsdk:"content"`
+	Decrypt          types.Bool     `tfsdk:"decrypt"`
+	FailIfMissing    types.Bool     `tfsdk:"fail_if_missing"`
+	RawCiphertext    types.String   `tfsdk:"raw_ciphertext"`
+	StoreContent     types.Bool     `tfsdk:"store_content"`
+	MaxContentBytes  types.Int64    `tfsdk:"max_content_bytes"`
+	ContentTruncated types.Bool     `tfsdk:"content_truncated"`
+	ContentEncoding  types.String   `tfsdk:"content_encoding"`
+	AttachmentName   types.String   `tfsdk:"attachment_name"`
+	AttachmentData   types.String   `tfsdk:"attachment_data"`
+	AttachmentPath   types.String   `tfsdk:"attachment_output_path"`
+	MetadataOnly     types.Bool     `tfsdk:"metadata_only"`
+	FollowRedirects  types.Bool     `tfsdk:"follow_redirects"`
+	SizeBytes        types.Int64    `tfsdk:"size_bytes"`
+	ExpectedSHA256   types.String   `tfsdk:"expected_sha256"`
+	AttachmentSHA256 types.String   `tfsdk:"attachment_sha256"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	MimeType         types.String   `tfsdk:"mime_type"`
+	CommentCount     types.Int64    `tfsdk:"comment_count"`
+	PasswordProtect  types.Bool     `tfsdk:"is_password_protected"`
 }
 
 func (d *PasteDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,20 +92,116 @@ func (d *PasteDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 			},
 			"url": schema.StringAttribute{
-				MarkdownDescription: "Full URL of the paste including master key",
-				Required:            true,
+				MarkdownDescription: "Full URL of the paste including master key. Exactly one of `url` or `paste_id` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("paste_id")),
+				},
+			},
+			"paste_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a paste on the provider host, such as the `id` of a `pastebin_paste` resource. Requires `master_key`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("master_key")),
+				},
+			},
+			"master_key": schema.StringAttribute{
+				MarkdownDescription: "Key to decrypt the paste given by `paste_id`, such as the `master_key` of a `pastebin_paste` resource",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("paste_id")),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"follow_redirects": schema.BoolAttribute{
+				MarkdownDescription: "Resolve `url` by following its redirects before reading the paste, for shortened links. " +
+					"The `#key` fragment is kept. At most 10 redirects are followed, and never from https to http",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("url")),
+				},
 			},
 			"password": schema.StringAttribute{
 				MarkdownDescription: "Password to decrypt the paste (if password protected)",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"basic_auth_username": schema.StringAttribute{
+				MarkdownDescription: "Username for HTTP basic authentication when reading this paste, overriding the provider `username` " +
+					"for pastes behind a different reverse proxy. Requires `basic_auth_password`",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("basic_auth_password")),
+				},
+			},
+			"basic_auth_password": schema.StringAttribute{
+				MarkdownDescription: "Password for HTTP basic authentication when reading this paste, overriding the provider `password`. " +
+					"Requires `basic_auth_username`",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("basic_auth_username")),
+				},
+			},
 			"confirm_burn": schema.BoolAttribute{
-				MarkdownDescription: "Confirm reading a burn-after-reading paste (will delete it)",
-				Optional:            true,
+				MarkdownDescription: "Confirm reading a burn-after-reading paste (will delete it). " +
+					"Requires `i_understand_this_deletes_the_paste = true`, since every refresh reads the paste again",
+				Optional: true,
+			},
+			"i_understand_this_deletes_the_paste": schema.BoolAttribute{
+				MarkdownDescription: "Acknowledge that `confirm_burn` deletes a burn-after-reading paste on the first read, " +
+					"after which later plans fail to read it",
+				Optional: true,
 			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "The content of the paste",
+				MarkdownDescription: "The content of the paste. Null when `store_content` or `decrypt` is false",
+				Computed:            true,
+			},
+			"decrypt": schema.BoolAttribute{
+				MarkdownDescription: "Decrypt the paste. Set to false to back up a paste as stored by the instance in `raw_ciphertext`, " +
+					"without the master key or password; the content, attachment and password attributes are then null, " +
+					"and the paste is read without failing over to the provider `hosts`. The instance deletes a burn-after-reading paste " +
+					"when it is read, encrypted or not. Defaults to true",
+				Optional: true,
+			},
+			"fail_if_missing": schema.BoolAttribute{
+				MarkdownDescription: "Fail when the paste does not exist, has expired or was burned. Set to false to return null attributes " +
+					"with a warning instead, so reading a list of possibly expired links does not break the plan. An unreachable instance, " +
+					"a wrong password, an unconfirmed burn and any other error still fail. Defaults to true",
+				Optional: true,
+			},
+			"raw_ciphertext": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded JSON document of the encrypted paste, with its ciphertext, cipher parameters and metadata, " +
+					"when `decrypt` is false. Decrypting it later still needs the master key of the paste, and its password if it has one. " +
+					"Null when `decrypt` is true",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"store_content": schema.BoolAttribute{
+				MarkdownDescription: "Store the content of the paste in `content`. Set to false to keep the possibly large and sensitive text " +
+					"out of state, for example when the paste is only read to confirm that it exists, and only return its metadata. " +
+					"Use `metadata_only` to do the same for attachments. Defaults to true",
+				Optional: true,
+			},
+			"max_content_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Truncate `content` to at most this many bytes, for a preview of a large paste. " +
+					"Pastes are encrypted, so the whole paste is still downloaded and decrypted before it is truncated; " +
+					"only the state is kept small. A multi-byte character is never split",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"content_encoding": schema.StringAttribute{
+				MarkdownDescription: "Character encoding the paste text is stored in, such as `latin1`, `windows-1251` or `shift_jis`. " +
+					"When set, `content` is converted from it to UTF-8. Names follow the WHATWG Encoding Standard, " +
+					"so `latin1` is read as `windows-1252`. Defaults to UTF-8",
+				Optional: true,
+			},
+			"content_truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether `content` was truncated to `max_content_bytes`. Null when `store_content` is false",
 				Computed:            true,
 			},
 			"attachment_name": schema.StringAttribute{
@@ -77,6 +213,35 @@ func (d *PasteDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"attachment_output_path": schema.StringAttribute{
+				MarkdownDescription: "Write the base64 encoded attachment to this file instead of storing it in `attachment_data`. " +
+					"Recommended for large attachments, which are then encoded in chunks and kept out of state",
+				Optional: true,
+			},
+			"metadata_only": schema.BoolAttribute{
+				MarkdownDescription: "Only return the attachment metadata, leaving `attachment_data` null. " +
+					"Keeps state small when only the name, MIME type and size are needed",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("attachment_output_path")),
+				},
+			},
+			"size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the attachment in bytes (if paste is an attachment)",
+				Computed:            true,
+			},
+			"expected_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA-256 the attachment must match. The read fails, before anything is written " +
+					"to `attachment_output_path`, when the paste has no attachment or the checksum differs",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(sha256Pattern, "must be a hex encoded SHA-256 checksum"),
+				},
+			},
+			"attachment_sha256": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA-256 of the attachment (if paste is an attachment)",
+				Computed:            true,
+			},
 			"mime_type": schema.StringAttribute{
 				MarkdownDescription: "MIME type of attachment (if paste is an attachment)",
 				Computed:            true,
@@ -85,10 +250,70 @@ func (d *PasteDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				MarkdownDescription: "Number of comments on the paste",
 				Computed:            true,
 			},
+			"is_password_protected": schema.BoolAttribute{
+				MarkdownDescription: "Whether the paste is protected by a password. The password is part of the decryption key, " +
+					"so a paste only opens with a password when it was created with one",
+				Computed: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
+func (d *PasteDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data PasteDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Burning a paste is destructive, so require an explicit acknowledgement
+	if data.ConfirmBurn.ValueBool() && !data.ConfirmDelete.IsUnknown() && !data.ConfirmDelete.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("i_understand_this_deletes_the_paste"),
+			"Missing Burn Acknowledgement",
+			"Setting confirm_burn = true deletes a burn-after-reading paste the first time it is read, "+
+				"and every plan or refresh reads it again. Set i_understand_this_deletes_the_paste = true to confirm.",
+		)
+	}
+
+	// Encrypted pastes can neither be verified nor written out
+	if !data.Decrypt.IsNull() && !data.Decrypt.IsUnknown() && !data.Decrypt.ValueBool() {
+		attributes := []struct {
+			name  string
+			value types.String
+		}{
+			{"expected_sha256", data.ExpectedSHA256},
+			{"attachment_output_path", data.AttachmentPath},
+		}
+
+		for _, a := range attributes {
+			if !a.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(a.name),
+					"Decryption Required",
+					fmt.Sprintf("%s needs the decrypted paste, but decrypt is false. Remove %s or set decrypt = true.", a.name, a.name),
+				)
+			}
+		}
+	}
+
+	if !data.ContentEncoding.IsNull() && !data.ContentEncoding.IsUnknown() {
+		if _, err := contentEncoding(data.ContentEncoding.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_encoding"),
+				"Unknown Content Encoding",
+				fmt.Sprintf("%q is not a known character encoding, such as utf-8, latin1 or shift_jis: %s", data.ContentEncoding.ValueString(), err),
+			)
+		}
+	}
+}
+
 func (d *PasteDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -119,10 +344,48 @@ func (d *PasteDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Parse the paste URL
-	pasteURL, err := url.Parse(data.URL.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse paste URL: %s", err))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Parse the paste URL, or build it from the ID and key on the
+	// provider host
+	var pasteURL *url.URL
+	if data.URL.IsNull() {
+		pasteURL = d.providerData.pasteURL(data.PasteID.ValueString(), data.MasterKey.ValueString())
+	} else {
+		var err error
+		pasteURL, err = url.Parse(data.URL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse paste URL: %s", redactError(err)))
+			return
+		}
+
+		if data.FollowRedirects.ValueBool() {
+			client := newRedirectClient(d.providerData.httpTransport())
+			pasteURL, err = resolvePasteURL(ctx, client, pasteURL)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("url"),
+					"Unable to Resolve Paste URL",
+					fmt.Sprintf("Unable to follow the redirects of the paste URL: %s", redactError(err)),
+				)
+				return
+			}
+
+			tflog.Debug(ctx, "resolved paste URL", map[string]interface{}{
+				"host": pasteURL.Host,
+			})
+		}
+	}
+
+	if !data.Decrypt.IsNull() && !data.Decrypt.ValueBool() {
+		d.readCiphertext(ctx, data, *pasteURL, resp)
 		return
 	}
 
@@ -135,29 +398,264 @@ func (d *PasteDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		ConfirmBurn: confirmBurn,
 	}
 
+	// Credentials for the paste override those of the provider
+	var clientOptions []pastebin.Option
+	secrets := []string{string(password)}
+	if !data.BasicAuthUser.IsNull() {
+		clientOptions = append(clientOptions, pastebin.WithBasicAuth(data.BasicAuthUser.ValueString(), data.BasicAuthPass.ValueString()))
+		secrets = append(secrets, data.BasicAuthPass.ValueString())
+	}
+
 	// Read the paste
-	result, err := d.providerData.Client.ShowPaste(ctx, *pasteURL, options)
+	result, err := callWithFailover(ctx, d.providerData.hostClients(nil, clientOptions...), opShowPaste, (*pastebin.Client).ShowPaste, *pasteURL, options, d.providerData.movePasteURL)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read paste: %s", err))
+		if isTransportError(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reach the pastebin instance: %s", d.providerData.redactError(err, secrets...)))
+			return
+		}
+
+		if !confirmBurn && isBurnConfirmationError(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("confirm_burn"),
+				"Burn Confirmation Required",
+				fmt.Sprintf("The paste is deleted after it is read, and reading it requires confirm_burn = true and "+
+					"i_understand_this_deletes_the_paste = true. Only set them if the paste should be burned now: %s",
+					d.providerData.redactError(err, secrets...)),
+			)
+			return
+		}
+
+		if !data.FailIfMissing.IsNull() && !data.FailIfMissing.ValueBool() && isPasteNotFoundError(err) {
+			d.readMissing(ctx, data, d.providerData.redactError(err, secrets...), resp)
+			return
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Paste Unavailable",
+			fmt.Sprintf("The paste could not be read. It may not exist, may have expired, may have already been burned after reading, "+
+				"or the password may be wrong: %s", d.providerData.redactError(err, secrets...)),
+		)
 		return
 	}
 
+	var attachmentSHA256 string
+	if result.Paste.AttachmentName != "" {
+		attachmentSHA256 = contentSHA256(result.Paste.Attachement)
+	}
+
+	// Verify the attachment before anything is written or stored
+	if !data.ExpectedSHA256.IsNull() {
+		if result.Paste.AttachmentName == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_sha256"),
+				"Missing Attachment",
+				"expected_sha256 is set, but the paste has no attachment to verify.",
+			)
+			return
+		}
+
+		if !strings.EqualFold(attachmentSHA256, data.ExpectedSHA256.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_sha256"),
+				"Attachment Checksum Mismatch",
+				fmt.Sprintf("The attachment %q has SHA-256 %s, expected %s. It may have been tampered with or the link may point to another paste.",
+					result.Paste.AttachmentName, attachmentSHA256, strings.ToLower(data.ExpectedSHA256.ValueString())),
+			)
+			return
+		}
+	}
+
 	// Map response to data source model
 	data.ID = types.StringValue(result.PasteID)
-	data.Content = types.StringValue(string(result.Paste.Data))
+	data.RawCiphertext = types.StringNull()
+	data.Content = types.StringNull()
+	data.ContentTruncated = types.BoolNull()
+	if data.StoreContent.IsNull() || data.StoreContent.ValueBool() {
+		// Decode before truncating, so characters are not cut in half
+		content, err := decodeContent(result.Paste.Data, data.ContentEncoding.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_encoding"),
+				"Unable to Decode Content",
+				fmt.Sprintf("The paste content could not be decoded from %s: %s", data.ContentEncoding.ValueString(), err),
+			)
+			return
+		}
+
+		truncated := false
+		if !data.MaxContentBytes.IsNull() {
+			content, truncated = truncateContent(content, int(data.MaxContentBytes.ValueInt64()))
+		}
+
+		data.Content = types.StringValue(string(content))
+		data.ContentTruncated = types.BoolValue(truncated)
+	}
 	data.CommentCount = types.Int64Value(int64(result.CommentCount))
 
+	// The paste decrypted, so the password was required exactly when one
+	// was given
+	data.PasswordProtect = types.BoolValue(len(password) > 0)
+
 	// Handle attachment data if present
 	if result.Paste.AttachmentName != "" {
 		data.AttachmentName = types.StringValue(result.Paste.AttachmentName)
 		data.MimeType = types.StringValue(result.Paste.MimeType)
 
-		// Convert attachment to base64 string
-		if len(result.Paste.Attachement) > 0 {
-			data.AttachmentData = types.StringValue(base64.StdEncoding.EncodeToString(result.Paste.Attachement))
+		data.SizeBytes = types.Int64Value(int64(len(result.Paste.Attachement)))
+		data.AttachmentSHA256 = types.StringValue(attachmentSHA256)
+
+		// Convert attachment to base64, streaming it to disk if requested
+		if len(result.Paste.Attachement) > 0 && !data.MetadataOnly.ValueBool() {
+			if !data.AttachmentPath.IsNull() {
+				if err := writeBase64File(data.AttachmentPath.ValueString(), result.Paste.Attachement); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("attachment_output_path"),
+						"Unable to Write Attachment",
+						fmt.Sprintf("Unable to write attachment to %s: %s", data.AttachmentPath.ValueString(), err),
+					)
+					return
+				}
+			} else {
+				data.AttachmentData = types.StringValue(base64.StdEncoding.EncodeToString(result.Paste.Attachement))
+			}
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readCiphertext reads the paste at pasteURL without decrypting it, for
+// decrypt = false, and saves it to the state in resp.
+func (d *PasteDataSource) readCiphertext(ctx context.Context, data PasteDataSourceModel, pasteURL url.URL, resp *datasource.ReadResponse) {
+	// Credentials for the paste override those of the provider
+	username, password := d.providerData.Username, d.providerData.Password
+	var secrets []string
+	if !data.BasicAuthUser.IsNull() {
+		username, password = data.BasicAuthUser.ValueString(), data.BasicAuthPass.ValueString()
+		secrets = append(secrets, password)
+	}
+
+	client := newRedirectClient(d.providerData.httpTransport())
+	result, err := fetchCiphertext(ctx, client, pasteURL, d.providerData.Headers, username, password)
+	if err != nil {
+		if isTransportError(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reach the pastebin instance: %s", d.providerData.redactError(err, secrets...)))
+			return
+		}
+
+		if !data.FailIfMissing.IsNull() && !data.FailIfMissing.ValueBool() && isPasteNotFoundError(err) {
+			d.readMissing(ctx, data, d.providerData.redactError(err, secrets...), resp)
+			return
 		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Paste Unavailable",
+			fmt.Sprintf("The paste could not be read. It may not exist, may have expired or may have already been burned after reading: %s",
+				d.providerData.redactError(err, secrets...)),
+		)
+		return
 	}
 
+	tflog.Debug(ctx, "read paste without decrypting it", map[string]interface{}{
+		"paste_id":       result.PasteID,
+		"document_bytes": len(result.Document),
+	})
+
+	data.ID = types.StringValue(result.PasteID)
+	data.RawCiphertext = types.StringValue(base64.StdEncoding.EncodeToString(result.Document))
+	data.CommentCount = types.Int64Value(int64(result.CommentCount))
+
+	// Nothing was decrypted, so nothing is known about the content
+	data.Content = types.StringNull()
+	data.ContentTruncated = types.BoolNull()
+	data.AttachmentName = types.StringNull()
+	data.AttachmentData = types.StringNull()
+	data.SizeBytes = types.Int64Null()
+	data.AttachmentSHA256 = types.StringNull()
+	data.MimeType = types.StringNull()
+	data.PasswordProtect = types.BoolNull()
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// readMissing saves a paste that could not be read to the state in resp,
+// with every computed attribute null, for fail_if_missing = false. reason
+// is the redacted error of the read.
+func (d *PasteDataSource) readMissing(ctx context.Context, data PasteDataSourceModel, reason string, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "paste could not be read, returning null attributes", map[string]interface{}{
+		"error": reason,
+	})
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("fail_if_missing"),
+		"Paste Not Found",
+		fmt.Sprintf("The paste could not be read, so its attributes are null. It may not exist, may have expired "+
+			"or may have already been burned after reading: %s", reason),
+	)
+
+	data.ID = types.StringNull()
+	data.Content = types.StringNull()
+	data.ContentTruncated = types.BoolNull()
+	data.RawCiphertext = types.StringNull()
+	data.AttachmentName = types.StringNull()
+	data.AttachmentData = types.StringNull()
+	data.SizeBytes = types.Int64Null()
+	data.AttachmentSHA256 = types.StringNull()
+	data.MimeType = types.StringNull()
+	data.CommentCount = types.Int64Null()
+	data.PasswordProtect = types.BoolNull()
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// truncateContent returns at most max bytes of content, cutting before a
+// multi-byte UTF-8 character rather than through it, and whether anything
+// was cut.
+func truncateContent(content []byte, max int) ([]byte, bool) {
+	if len(content) <= max {
+		return content, false
+	}
+
+	end := max
+	for end > 0 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+
+	return content[:end], true
+}
+
+// writeBase64File writes data base64 encoded to the file at name. The
+// encoding is streamed so the encoded copy is never held in memory.
+func writeBase64File(name string, data []byte) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := writeBase64(f, data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeBase64 writes data base64 encoded to w in fixed size chunks.
+func writeBase64(w io.Writer, data []byte) error {
+	bw := bufio.NewWriterSize(w, base64ChunkSize)
+	enc := base64.NewEncoder(base64.StdEncoding, bw)
+
+	if _, err := enc.Write(data); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
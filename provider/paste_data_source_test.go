@@ -1,12 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -38,8 +49,12 @@ func TestPasteDataSource_Schema(t *testing.T) {
 
 	// Check that all expected attributes are present
 	expectedAttributes := []string{
-		"id", "url", "password", "confirm_burn", "content",
-		"attachment_name", "attachment_data", "mime_type", "comment_count",
+		"id", "url", "password", "confirm_burn", "i_understand_this_deletes_the_paste", "content",
+		"attachment_name", "attachment_data", "attachment_output_path", "mime_type", "comment_count",
+		"metadata_only", "follow_redirects", "size_bytes", "paste_id", "master_key",
+		"expected_sha256", "attachment_sha256", "store_content",
+		"max_content_bytes", "content_truncated", "basic_auth_username", "basic_auth_password",
+		"is_password_protected", "decrypt", "raw_ciphertext", "fail_if_missing",
 	}
 
 	for _, attr := range expectedAttributes {
@@ -47,26 +62,30 @@ func TestPasteDataSource_Schema(t *testing.T) {
 		assert.True(t, exists, "Expected attribute %s to be present in schema", attr)
 	}
 
-	// Verify required attributes
+	// Verify the paste is given by either url or paste_id
 	urlAttr := resp.Schema.Attributes["url"]
-	assert.True(t, urlAttr.IsRequired(), "URL attribute should be required")
+	assert.True(t, urlAttr.IsOptional(), "URL attribute should be optional")
 
 	// Verify computed attributes
-	computedAttrs := []string{"id", "content", "attachment_name", "attachment_data", "mime_type", "comment_count"}
+	computedAttrs := []string{"id", "content", "attachment_name", "attachment_data", "mime_type", "comment_count", "size_bytes", "attachment_sha256", "is_password_protected", "raw_ciphertext"}
 	for _, attrName := range computedAttrs {
 		attr := resp.Schema.Attributes[attrName]
 		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
 	}
 
 	// Verify optional attributes
-	optionalAttrs := []string{"password", "confirm_burn"}
+	optionalAttrs := []string{"password", "confirm_burn", "i_understand_this_deletes_the_paste", "attachment_output_path", "metadata_only", "follow_redirects", "paste_id", "master_key", "expected_sha256", "store_content", "basic_auth_username", "basic_auth_password", "decrypt", "fail_if_missing"}
 	for _, attrName := range optionalAttrs {
 		attr := resp.Schema.Attributes[attrName]
 		assert.True(t, attr.IsOptional(), "Attribute %s should be optional", attrName)
 	}
 
+	// Verify the timeouts block
+	_, exists := resp.Schema.Blocks["timeouts"]
+	assert.True(t, exists, "Expected timeouts block to be present in schema")
+
 	// Verify sensitive attributes
-	sensitiveAttrs := []string{"password", "attachment_data"}
+	sensitiveAttrs := []string{"password", "attachment_data", "master_key", "basic_auth_username", "basic_auth_password", "raw_ciphertext"}
 	for _, attrName := range sensitiveAttrs {
 		attr := resp.Schema.Attributes[attrName]
 		assert.True(t, attr.IsSensitive(), "Attribute %s should be sensitive", attrName)
@@ -76,16 +95,13 @@ func TestPasteDataSource_Schema(t *testing.T) {
 func TestPasteDataSource_Configure_Success(t *testing.T) {
 	d := &PasteDataSource{}
 	ctx := context.Background()
-	
+
 	// Create mock provider data
 	testURL, _ := url.Parse("https://example.com")
 	providerData := &ProviderData{
-		Client:           pastebin.NewClient(*testURL),
-		Expire:           "1week",
-		Formatter:        "plaintext",
-		GZip:             false,
-		OpenDiscussion:   false,
-		BurnAfterReading: false,
+		Client:    pastebin.NewClient(*testURL),
+		Expire:    "1week",
+		Formatter: "plaintext",
 	}
 
 	req := datasource.ConfigureRequest{
@@ -102,7 +118,7 @@ func TestPasteDataSource_Configure_Success(t *testing.T) {
 func TestPasteDataSource_Configure_InvalidProviderData(t *testing.T) {
 	d := &PasteDataSource{}
 	ctx := context.Background()
-	
+
 	req := datasource.ConfigureRequest{
 		ProviderData: "invalid", // Wrong type
 	}
@@ -117,7 +133,7 @@ func TestPasteDataSource_Configure_InvalidProviderData(t *testing.T) {
 func TestPasteDataSource_Configure_NilProviderData(t *testing.T) {
 	d := &PasteDataSource{}
 	ctx := context.Background()
-	
+
 	req := datasource.ConfigureRequest{
 		ProviderData: nil,
 	}
@@ -132,7 +148,7 @@ func TestPasteDataSource_Configure_NilProviderData(t *testing.T) {
 func TestNewPasteDataSource(t *testing.T) {
 	dataSource := NewPasteDataSource()
 	assert.NotNil(t, dataSource)
-	
+
 	// Verify it's the correct type
 	_, ok := dataSource.(*PasteDataSource)
 	assert.True(t, ok)
@@ -141,14 +157,20 @@ func TestNewPasteDataSource(t *testing.T) {
 func TestPasteDataSourceModel_DefaultValues(t *testing.T) {
 	// Test that the model can be created and has expected zero values
 	model := PasteDataSourceModel{}
-	
+
 	assert.True(t, model.ID.IsNull())
 	assert.True(t, model.URL.IsNull())
+	assert.True(t, model.PasteID.IsNull())
+	assert.True(t, model.MasterKey.IsNull())
 	assert.True(t, model.Password.IsNull())
 	assert.True(t, model.ConfirmBurn.IsNull())
+	assert.True(t, model.ConfirmDelete.IsNull())
 	assert.True(t, model.Content.IsNull())
 	assert.True(t, model.AttachmentName.IsNull())
 	assert.True(t, model.AttachmentData.IsNull())
+	assert.True(t, model.AttachmentPath.IsNull())
+	assert.True(t, model.MetadataOnly.IsNull())
+	assert.True(t, model.SizeBytes.IsNull())
 	assert.True(t, model.MimeType.IsNull())
 	assert.True(t, model.CommentCount.IsNull())
 }
@@ -166,7 +188,7 @@ func TestPasteDataSourceModel_WithValues(t *testing.T) {
 		MimeType:       types.StringValue("text/plain"),
 		CommentCount:   types.Int64Value(5),
 	}
-	
+
 	assert.Equal(t, "test-id", model.ID.ValueString())
 	assert.Equal(t, "https://example.com/paste/test-id", model.URL.ValueString())
 	assert.Equal(t, "secret", model.Password.ValueString())
@@ -214,9 +236,415 @@ func TestPasteDataSource_URLValidation(t *testing.T) {
 	}
 }
 
-// Mock tests for Read would require mocking the pastebin client
-// Since we don't have access to mock the external client easily, we focus on
-// testing the logic we can control (schema, configuration, model validation)
+func TestPasteDataSource_ValidateConfig_ConfirmBurn(t *testing.T) {
+	tests := []struct {
+		name          string
+		confirmBurn   tftypes.Value
+		confirmDelete tftypes.Value
+		expectError   bool
+	}{
+		{
+			name:          "confirm_burn unset",
+			confirmBurn:   tftypes.NewValue(tftypes.Bool, nil),
+			confirmDelete: tftypes.NewValue(tftypes.Bool, nil),
+			expectError:   false,
+		},
+		{
+			name:          "confirm_burn without acknowledgement",
+			confirmBurn:   tftypes.NewValue(tftypes.Bool, true),
+			confirmDelete: tftypes.NewValue(tftypes.Bool, nil),
+			expectError:   true,
+		},
+		{
+			name:          "confirm_burn with false acknowledgement",
+			confirmBurn:   tftypes.NewValue(tftypes.Bool, true),
+			confirmDelete: tftypes.NewValue(tftypes.Bool, false),
+			expectError:   true,
+		},
+		{
+			name:          "confirm_burn with acknowledgement",
+			confirmBurn:   tftypes.NewValue(tftypes.Bool, true),
+			confirmDelete: tftypes.NewValue(tftypes.Bool, true),
+			expectError:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &PasteDataSource{}
+			req := datasource.ValidateConfigRequest{
+				Config: testDataSourceConfig(t, d, map[string]tftypes.Value{
+					"url":                                 tftypes.NewValue(tftypes.String, "https://example.com/?abc#key"),
+					"confirm_burn":                        tt.confirmBurn,
+					"i_understand_this_deletes_the_paste": tt.confirmDelete,
+				}),
+			}
+			resp := &datasource.ValidateConfigResponse{}
+
+			d.ValidateConfig(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+// testDataSourceConfig builds a data source configuration with the given
+// attribute values, leaving every other attribute null.
+func testDataSourceConfig(t *testing.T, d datasource.DataSource, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		attrs[name] = value
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attrs),
+	}
+}
+
+func TestPasteDataSource_Read_FakeServer(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	providerData := fake.providerData(t, nil)
+	ctx := context.Background()
+
+	text, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), pastebin.CreatePasteOptions{
+		Formatter: "plaintext",
+		Expire:    "1day",
+		Compress:  pastebin.CompressionAlgorithmGZip,
+		Password:  []byte("hunter2"),
+	})
+	require.NoError(t, err)
+
+	attachment, err := providerData.Client.CreatePaste(ctx, []byte("%PDF-1.7"), pastebin.CreatePasteOptions{
+		AttachmentName: "report.pdf",
+		Formatter:      "plaintext",
+		Expire:         "1day",
+		Compress:       pastebin.CompressionAlgorithmNone,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		values          map[string]tftypes.Value
+		expectedSummary string
+		check           func(t *testing.T, data PasteDataSourceModel)
+	}{
+		{
+			name: "text paste",
+			values: map[string]tftypes.Value{
+				"url":      tftypes.NewValue(tftypes.String, text.PasteURL.String()),
+				"password": tftypes.NewValue(tftypes.String, "hunter2"),
+			},
+			check: func(t *testing.T, data PasteDataSourceModel) {
+				assert.Equal(t, text.PasteID, data.ID.ValueString())
+				assert.Equal(t, "Hello, World!", data.Content.ValueString())
+				assert.False(t, data.ContentTruncated.ValueBool())
+				assert.True(t, data.PasswordProtect.ValueBool())
+				assert.Equal(t, int64(0), data.CommentCount.ValueInt64())
+				assert.True(t, data.AttachmentName.IsNull())
+			},
+		},
+		{
+			name: "paste ID and key on the provider host",
+			values: map[string]tftypes.Value{
+				"paste_id":   tftypes.NewValue(tftypes.String, text.PasteID),
+				"master_key": tftypes.NewValue(tftypes.String, text.PasteURL.Fragment),
+				"password":   tftypes.NewValue(tftypes.String, "hunter2"),
+			},
+			check: func(t *testing.T, data PasteDataSourceModel) {
+				assert.Equal(t, "Hello, World!", data.Content.ValueString())
+			},
+		},
+		{
+			name: "attachment",
+			values: map[string]tftypes.Value{
+				"url": tftypes.NewValue(tftypes.String, attachment.PasteURL.String()),
+			},
+			check: func(t *testing.T, data PasteDataSourceModel) {
+				assert.Equal(t, "report.pdf", data.AttachmentName.ValueString())
+				assert.Equal(t, "application/pdf", data.MimeType.ValueString())
+				assert.Equal(t, int64(len("%PDF-1.7")), data.SizeBytes.ValueInt64())
+				assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("%PDF-1.7")), data.AttachmentData.ValueString())
+				assert.Equal(t, contentSHA256([]byte("%PDF-1.7")), data.AttachmentSHA256.ValueString())
+				assert.False(t, data.PasswordProtect.ValueBool())
+			},
+		},
+		{
+			name: "wrong password",
+			values: map[string]tftypes.Value{
+				"url":      tftypes.NewValue(tftypes.String, text.PasteURL.String()),
+				"password": tftypes.NewValue(tftypes.String, "wrong"),
+			},
+			expectedSummary: "Paste Unavailable",
+		},
+		{
+			name: "missing paste",
+			values: map[string]tftypes.Value{
+				"paste_id":   tftypes.NewValue(tftypes.String, "ffffffffffffffff"),
+				"master_key": tftypes.NewValue(tftypes.String, text.PasteURL.Fragment),
+			},
+			expectedSummary: "Paste Unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &PasteDataSource{providerData: providerData}
+			config := testDataSourceConfig(t, d, tt.values)
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			if tt.expectedSummary != "" {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				assert.Equal(t, tt.expectedSummary, resp.Diagnostics.Errors()[0].Summary())
+				assert.NotContains(t, resp.Diagnostics.Errors()[0].Detail(), "hunter2")
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var data PasteDataSourceModel
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+			tt.check(t, data)
+		})
+	}
+}
+
+func TestPasteDataSource_Read_FailIfMissing(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	providerData := fake.providerData(t, nil)
+	ctx := context.Background()
+
+	created, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), pastebin.CreatePasteOptions{
+		Formatter: "plaintext",
+		Expire:    "1day",
+		Compress:  pastebin.CompressionAlgorithmNone,
+		Password:  []byte("hunter2"),
+	})
+	require.NoError(t, err)
+
+	missingID := tftypes.NewValue(tftypes.String, "ffffffffffffffff")
+	masterKey := tftypes.NewValue(tftypes.String, created.PasteURL.Fragment)
+	lenient := tftypes.NewValue(tftypes.Bool, false)
+
+	// An instance failing with an error other than a missing paste
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, "Invalid data.")
+	}))
+	t.Cleanup(server.Close)
+	failingData := (&fakePrivateBin{Server: server}).providerData(t, nil)
+
+	tests := []struct {
+		name            string
+		values          map[string]tftypes.Value
+		providerData    *ProviderData
+		closed          bool
+		expectedError   string
+		expectedWarning string
+	}{
+		{
+			name: "missing paste fails by default",
+			values: map[string]tftypes.Value{
+				"paste_id":   missingID,
+				"master_key": masterKey,
+			},
+			expectedError: "Paste Unavailable",
+		},
+		{
+			name: "missing paste",
+			values: map[string]tftypes.Value{
+				"paste_id":        missingID,
+				"master_key":      masterKey,
+				"fail_if_missing": lenient,
+			},
+			expectedWarning: "Paste Not Found",
+		},
+		{
+			name: "missing paste without decrypting",
+			values: map[string]tftypes.Value{
+				"paste_id":        missingID,
+				"master_key":      masterKey,
+				"decrypt":         tftypes.NewValue(tftypes.Bool, false),
+				"fail_if_missing": lenient,
+			},
+			expectedWarning: "Paste Not Found",
+		},
+		{
+			name: "wrong password",
+			values: map[string]tftypes.Value{
+				"url":             tftypes.NewValue(tftypes.String, created.PasteURL.String()),
+				"password":        tftypes.NewValue(tftypes.String, "wrong"),
+				"fail_if_missing": lenient,
+			},
+			expectedError: "Paste Unavailable",
+		},
+		{
+			name: "unrelated error",
+			values: map[string]tftypes.Value{
+				"paste_id":        missingID,
+				"master_key":      masterKey,
+				"fail_if_missing": lenient,
+			},
+			providerData:  failingData,
+			expectedError: "Paste Unavailable",
+		},
+		{
+			name: "unrelated error without decrypting",
+			values: map[string]tftypes.Value{
+				"paste_id":        missingID,
+				"master_key":      masterKey,
+				"decrypt":         tftypes.NewValue(tftypes.Bool, false),
+				"fail_if_missing": lenient,
+			},
+			providerData:  failingData,
+			expectedError: "Paste Unavailable",
+		},
+		{
+			name: "unreachable instance",
+			values: map[string]tftypes.Value{
+				"paste_id":        missingID,
+				"master_key":      masterKey,
+				"fail_if_missing": lenient,
+			},
+			closed:        true,
+			expectedError: "Client Error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.closed {
+				fake.Close()
+			}
+
+			d := &PasteDataSource{providerData: providerData}
+			if tt.providerData != nil {
+				d.providerData = tt.providerData
+			}
+			config := testDataSourceConfig(t, d, tt.values)
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			if tt.expectedError != "" {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				assert.Equal(t, tt.expectedError, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Len(t, resp.Diagnostics.Warnings(), 1)
+			assert.Equal(t, tt.expectedWarning, resp.Diagnostics.Warnings()[0].Summary())
+
+			var data PasteDataSourceModel
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+			assert.True(t, data.ID.IsNull())
+			assert.True(t, data.Content.IsNull())
+			assert.True(t, data.CommentCount.IsNull())
+			assert.True(t, data.RawCiphertext.IsNull())
+		})
+	}
+}
+
+func TestPasteDataSource_Read_BurnConfirmation(t *testing.T) {
+	ctx := context.Background()
+
+	fake := newFakePrivateBin(t)
+	providerData := fake.providerData(t, nil)
+	created, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), pastebin.CreatePasteOptions{
+		Formatter:        "plaintext",
+		Expire:           "1day",
+		BurnAfterReading: true,
+	})
+	require.NoError(t, err)
+
+	// An instance failing with an error that merely mentions burning
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, "Paste could not be burned, storage is read-only.")
+	}))
+	t.Cleanup(server.Close)
+	failingData := (&fakePrivateBin{Server: server}).providerData(t, nil)
+
+	tests := []struct {
+		name          string
+		providerData  *ProviderData
+		url           string
+		expectedError string
+	}{
+		{
+			name:          "burn after reading paste",
+			providerData:  providerData,
+			url:           created.PasteURL.String(),
+			expectedError: "Burn Confirmation Required",
+		},
+		{
+			name:          "error mentioning burn",
+			providerData:  failingData,
+			url:           server.URL + "/?abcd1234#" + created.PasteURL.Fragment,
+			expectedError: "Paste Unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &PasteDataSource{providerData: tt.providerData}
+			config := testDataSourceConfig(t, d, map[string]tftypes.Value{
+				"url": tftypes.NewValue(tftypes.String, tt.url),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestPasteDataSource_Read_GZipTransport(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	fake.gzipResponses = true
+	providerData := fake.providerData(t, nil)
+	ctx := context.Background()
+
+	// The paste itself is compressed too, independently of the transport
+	created, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), pastebin.CreatePasteOptions{
+		Formatter: "plaintext",
+		Expire:    "1day",
+		Compress:  pastebin.CompressionAlgorithmGZip,
+	})
+	require.NoError(t, err)
+
+	d := &PasteDataSource{providerData: providerData}
+	config := testDataSourceConfig(t, d, map[string]tftypes.Value{
+		"url": tftypes.NewValue(tftypes.String, created.PasteURL.String()),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var content types.String
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("content"), &content).HasError())
+	assert.Equal(t, "Hello, World!", content.ValueString())
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	for _, req := range fake.requests {
+		assert.Contains(t, req.Header.Get("Accept-Encoding"), "gzip")
+	}
+}
 
 func TestPasteDataSource_Integration_Configure_And_Schema(t *testing.T) {
 	// Integration test that verifies configure and schema work together
@@ -305,4 +733,95 @@ func TestPasteDataSourceModel_SensitiveFields(t *testing.T) {
 	assert.Equal(t, "sensitive-data", model.AttachmentData.ValueString())
 	assert.False(t, model.Password.IsNull())
 	assert.False(t, model.AttachmentData.IsNull())
-}
\ No newline at end of file
+}
+
+func TestWriteBase64(t *testing.T) {
+	data := bytes.Repeat([]byte("attachment data\x00\xff"), 10000)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeBase64(&buf, data))
+
+	assert.Equal(t, base64.StdEncoding.EncodeToString(data), buf.String())
+}
+
+func TestWriteBase64File(t *testing.T) {
+	data := []byte("test data")
+	name := filepath.Join(t.TempDir(), "attachment.b64")
+
+	require.NoError(t, writeBase64File(name, data))
+
+	written, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "dGVzdCBkYXRh", string(written))
+}
+
+func TestWriteBase64File_InvalidPath(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "missing", "attachment.b64")
+
+	assert.Error(t, writeBase64File(name, []byte("test data")))
+}
+
+// benchmarkAttachmentSize matches a large 50MB attachment.
+const benchmarkAttachmentSize = 50 * 1024 * 1024
+
+// BenchmarkAttachmentBase64_String measures encoding the attachment into a
+// single string, as stored in attachment_data.
+func BenchmarkAttachmentBase64_String(b *testing.B) {
+	data := make([]byte, benchmarkAttachmentSize)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := io.WriteString(io.Discard, base64.StdEncoding.EncodeToString(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAttachmentBase64_Stream measures streaming the attachment, as
+// done for attachment_output_path.
+func BenchmarkAttachmentBase64_Stream(b *testing.B) {
+	data := make([]byte, benchmarkAttachmentSize)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := writeBase64(io.Discard, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTruncateContent(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		max               int
+		expected          string
+		expectedTruncated bool
+	}{
+		{"shorter", "hello", 10, "hello", false},
+		{"exact", "hello", 5, "hello", false},
+		{"longer", "hello world", 5, "hello", true},
+		{"multi-byte character kept whole", "aé", 3, "aé", false},
+		{"multi-byte character not split", "aéb", 2, "a", true},
+		{"four-byte character not split", "😀x", 3, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, truncated := truncateContent([]byte(tt.content), tt.max)
+			assert.Equal(t, tt.expected, string(content))
+			assert.Equal(t, tt.expectedTruncated, truncated)
+		})
+	}
+}
+
+func TestSHA256Pattern(t *testing.T) {
+	assert.True(t, sha256Pattern.MatchString(contentSHA256([]byte("attachment"))))
+	assert.True(t, sha256Pattern.MatchString(strings.ToUpper(contentSHA256([]byte("attachment")))))
+	assert.False(t, sha256Pattern.MatchString("abc123"))
+	assert.False(t, sha256Pattern.MatchString(strings.Repeat("g", 64)))
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// maxDeleteResponseSize bounds the response read when deleting a paste,
+// which is a short JSON status.
+const maxDeleteResponseSize = 64 << 10
+
+// deletePaste deletes the paste with pasteID and deleteToken from the
+// instance serving pasteURL, through the JSON API. The provider headers are
+// sent, and basic authentication when username is set. A paste that no
+// longer exists, such as one burned after reading, counts as deleted.
+func deletePaste(ctx context.Context, client *http.Client, pasteURL url.URL, pasteID, deleteToken string, headers []headerField, username, password string) error {
+	deleteURL := pasteDeleteURL(pasteURL, pasteID, deleteToken)
+	if deleteURL == "" {
+		return errors.New("the paste has no delete token")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, deleteURL, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range headers {
+		req.Header.Add(h.Name, h.Value)
+	}
+	req.Header.Set("X-Requested-With", "JSONHttpRequest")
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDeleteResponseSize)).Decode(&body); err != nil {
+		return fmt.Errorf("unable to decode the response: %w", err)
+	}
+
+	if body.Status != 0 && body.Message != pasteNotFound {
+		return fmt.Errorf("the instance returned an error: %s", body.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &PasteEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &PasteEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &PasteEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &PasteEphemeralResource{}
+
+// privateKeyEphemeralPaste is the private data key holding the paste
+// created by Open, for Close to delete.
+const privateKeyEphemeralPaste = "paste"
+
+func NewPasteEphemeralResource() ephemeral.EphemeralResource {
+	return &PasteEphemeralResource{}
+}
+
+// PasteEphemeralResource defines the ephemeral resource implementation.
+type PasteEphemeralResource struct {
+	providerData *ProviderData
+}
+
+// PasteEphemeralResourceModel describes the ephemeral resource data model.
+type PasteEphemeralResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Content          types.String `tfsdk:"content"`
+	AttachmentName   types.String `tfsdk:"attachment_name"`
+	Formatter        types.String `tfsdk:"formatter"`
+	Expire           types.String `tfsdk:"expire"`
+	Password         types.String `tfsdk:"password"`
+	OpenDiscussion   types.Bool   `tfsdk:"open_discussion"`
+	BurnAfterReading types.Bool   `tfsdk:"burn_after_reading"`
+	GZip             types.Bool   `tfsdk:"gzip"`
+	ExtraHeaders     types.Map    `tfsdk:"extra_headers"`
+	AllowBinary      types.Bool   `tfsdk:"allow_binary_content"`
+	URL              types.String `tfsdk:"url"`
+	DeleteToken      types.String `tfsdk:"delete_token"`
+}
+
+// ephemeralPaste is the private data recorded by Open.
+type ephemeralPaste struct {
+	PasteID      string            `json:"paste_id"`
+	DeleteToken  string            `json:"delete_token"`
+	URL          string            `json:"url"`
+	ExtraHeaders map[string]string `json:"extra_headers,omitempty"`
+}
+
+func (r *PasteEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste"
+}
+
+func (r *PasteEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a paste without writing its content or URL to state, for one-time secret delivery. " +
+			"A new paste is created every time Terraform opens the ephemeral resource, including during plan, and it is deleted " +
+			"when Terraform closes it at the end of the run. Requires Terraform 1.10 or later",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Paste identifier",
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content of the paste",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"attachment_name": schema.StringAttribute{
+				MarkdownDescription: "Name for the attachment (makes the paste an attachment)",
+				Optional:            true,
+			},
+			"formatter": schema.StringAttribute{
+				MarkdownDescription: "Text formatter (plaintext, markdown, syntaxhighlighting). Defaults to plaintext for attachments, otherwise to the provider `formatter`",
+				Optional:            true,
+			},
+			"expire": schema.StringAttribute{
+				MarkdownDescription: "Expiration time (5min, 10min, 1hour, 1day, 1week, 1month, 1year, never). Defaults to the provider `expire_by_formatter` entry for the formatter, then the provider `expire`",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password to protect the paste",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"open_discussion": schema.BoolAttribute{
+				MarkdownDescription: "Enable discussion/comments on the paste. Defaults to the provider `open_discussion`, or false",
+				Optional:            true,
+			},
+			"burn_after_reading": schema.BoolAttribute{
+				MarkdownDescription: "Delete the paste after first read. Defaults to the provider `burn_after_reading`, or false",
+				Optional:            true,
+			},
+			"gzip": schema.BoolAttribute{
+				MarkdownDescription: "Enable gzip compression. Defaults to the provider `gzip`, or true",
+				Optional:            true,
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Extra HTTP headers to include in requests for this paste. " +
+					"They take precedence over provider headers with the same name. `Accept-Encoding` cannot be set, as gzip is negotiated automatically",
+				Optional: true,
+			},
+			"allow_binary_content": schema.BoolAttribute{
+				MarkdownDescription: "Allow control characters and invalid UTF-8 in text pastes. " +
+					"By default such content is rejected, since it does not display correctly; attachments are never checked",
+				Optional: true,
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "URL of the created paste, including its key",
+			},
+			"delete_token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Delete token for the paste",
+			},
+		},
+	}
+}
+
+func (r *PasteEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *PasteEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data PasteEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The server rejects empty pastes with an unhelpful error, so catch
+	// them here. An attachment may legitimately be an empty file.
+	if !data.Content.IsUnknown() && data.Content.ValueString() == "" && data.AttachmentName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Empty Paste Content",
+			"The paste has no content. Set content to the text to share, "+
+				"or set attachment_name to upload content as an attachment.",
+		)
+	}
+
+	for name := range data.ExtraHeaders.Elements() {
+		if strings.EqualFold(name, acceptEncodingHeader) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers").AtMapKey(name),
+				"Unsupported Extra Header",
+				acceptEncodingDetail,
+			)
+		}
+	}
+}
+
+func (r *PasteEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data PasteEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// Use provider defaults if not specified
+	formatter := data.Formatter.ValueString()
+	if data.Formatter.IsNull() {
+		formatter = r.providerData.Formatter
+		if !data.AttachmentName.IsNull() {
+			formatter = attachmentFormatter
+		}
+	}
+
+	expire := data.Expire.ValueString()
+	if data.Expire.IsNull() {
+		expire = r.providerData.defaultExpire(formatter)
+	}
+
+	if err := r.providerData.checkExpire(expire); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expire"), "Never Expiring Paste Not Allowed", fmt.Sprintf("Unable to create paste: %s", err))
+		return
+	}
+
+	gzip := resolveBool(data.GZip, r.providerData.GZip, defaultGZip)
+	openDiscussion := resolveBool(data.OpenDiscussion, r.providerData.OpenDiscussion, false)
+	burnAfterReading := resolveBool(data.BurnAfterReading, r.providerData.BurnAfterReading, false)
+
+	password := data.Password.ValueString()
+	if data.Password.IsNull() {
+		password = r.providerData.DefaultPassword
+	}
+
+	compress := pastebin.CompressionAlgorithmNone
+	if gzip {
+		compress = pastebin.CompressionAlgorithmGZip
+	}
+
+	content := []byte(data.Content.ValueString())
+
+	// Text pastes are rendered in the browser, where binary data shows up
+	// garbled or truncated
+	if data.AttachmentName.IsNull() && !data.AllowBinary.ValueBool() {
+		if err := checkTextContent(content); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content"),
+				"Binary Paste Content",
+				fmt.Sprintf("Unable to create paste: %s. Set attachment_name to upload the content as an attachment, "+
+					"or set allow_binary_content = true to create the text paste anyway.", err),
+			)
+			return
+		}
+	}
+
+	// Fail before contacting the server if the paste is over the limit
+	if err := r.providerData.checkPasteSize(len(content)); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Paste Too Large", fmt.Sprintf("Unable to create paste: %s", err))
+		return
+	}
+
+	options := pastebin.CreatePasteOptions{
+		AttachmentName:   data.AttachmentName.ValueString(),
+		Formatter:        formatter,
+		Expire:           expire,
+		OpenDiscussion:   openDiscussion,
+		BurnAfterReading: burnAfterReading,
+		Compress:         compress,
+		Password:         []byte(password),
+	}
+
+	var headers map[string]string
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secrets := append(sensitiveHeaderValues(headers), password)
+	clients := r.providerData.hostClients(headers)
+	result, err := callWithFailover(ctx, clients, opCreatePaste, (*pastebin.Client).CreatePaste, content, options, nil)
+	if err != nil && r.providerData.retryUncompressed(ctx, options, err, secrets...) {
+		options.Compress = pastebin.CompressionAlgorithmNone
+		result, err = callWithFailover(ctx, clients, opCreatePaste, (*pastebin.Client).CreatePaste, content, options, nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create paste, got error: %s", r.providerData.redactError(err, secrets...)))
+		return
+	}
+
+	data.ID = types.StringValue(result.PasteID)
+	data.URL = types.StringValue(result.PasteURL.String())
+	data.DeleteToken = types.StringValue(result.DeleteToken)
+
+	// Keep what Close needs to delete the paste, without its key
+	pasteURL := result.PasteURL
+	pasteURL.Fragment = ""
+	pasteURL.RawFragment = ""
+	private, err := json.Marshal(ephemeralPaste{
+		PasteID:      result.PasteID,
+		DeleteToken:  result.DeleteToken,
+		URL:          pasteURL.String(),
+		ExtraHeaders: headers,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to encode private data: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyEphemeralPaste, private)...)
+
+	tflog.Trace(ctx, "opened an ephemeral paste", map[string]interface{}{
+		"paste_id": result.PasteID,
+	})
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *PasteEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, privateKeyEphemeralPaste)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var paste ephemeralPaste
+	if err := json.Unmarshal(private, &paste); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to decode private data: %s", err))
+		return
+	}
+
+	pasteURL, err := url.Parse(paste.URL)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to parse the paste URL: %s", err))
+		return
+	}
+
+	// Without a delete token the paste stays readable until it expires
+	if paste.DeleteToken == "" {
+		resp.Diagnostics.AddWarning(
+			"Paste Not Deleted",
+			fmt.Sprintf("Paste %s was left on the instance, because the instance returned no delete token. "+
+				"It stays readable by anyone with its URL until it expires or is burned after reading.", paste.PasteID),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	headers := withExtraHeaders(r.providerData.Headers, paste.ExtraHeaders)
+	secrets := append(sensitiveHeaderValues(paste.ExtraHeaders), paste.DeleteToken)
+	client := newRedirectClient(r.providerData.httpTransport())
+	if err := deletePaste(ctx, client, *pasteURL, paste.PasteID, paste.DeleteToken, headers, r.providerData.Username, r.providerData.Password); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Paste Not Deleted",
+			fmt.Sprintf("Paste %s could not be deleted, so it stays readable by anyone with its URL until it expires "+
+				"or is burned after reading: %s", paste.PasteID, r.providerData.redactError(err, secrets...)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted the ephemeral paste", map[string]interface{}{
+		"paste_id": paste.PasteID,
+	})
+}
+
+// withExtraHeaders returns headers with the extra ones added, replacing
+// those with the same name.
+func withExtraHeaders(headers []headerField, extra map[string]string) []headerField {
+	merged := slices.DeleteFunc(slices.Clone(headers), func(h headerField) bool {
+		for name := range extra {
+			if strings.EqualFold(h.Name, name) {
+				return true
+			}
+		}
+		return false
+	})
+
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		merged = append(merged, headerField{Name: name, Value: extra[name]})
+	}
+
+	return merged
+}
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasteEphemeralResource_Metadata(t *testing.T) {
+	r := &PasteEphemeralResource{}
+	ctx := context.Background()
+	req := ephemeral.MetadataRequest{
+		ProviderTypeName: "pastebin",
+	}
+	resp := &ephemeral.MetadataResponse{}
+
+	r.Metadata(ctx, req, resp)
+
+	assert.Equal(t, "pastebin_paste", resp.TypeName)
+}
+
+func TestPasteEphemeralResource_Schema(t *testing.T) {
+	r := &PasteEphemeralResource{}
+	ctx := context.Background()
+	req := ephemeral.SchemaRequest{}
+	resp := &ephemeral.SchemaResponse{}
+
+	r.Schema(ctx, req, resp)
+
+	require.NotNil(t, resp.Schema.Attributes)
+
+	// Check that all expected attributes are present
+	expectedAttributes := []string{
+		"id", "content", "attachment_name", "formatter", "expire",
+		"password", "open_discussion", "burn_after_reading", "gzip",
+		"extra_headers", "allow_binary_content", "url", "delete_token",
+	}
+
+	for _, attr := range expectedAttributes {
+		_, exists := resp.Schema.Attributes[attr]
+		assert.True(t, exists, "Expected attribute %s to be present in schema", attr)
+	}
+
+	// Verify computed attributes
+	computedAttrs := []string{"id", "url", "delete_token"}
+	for _, attrName := range computedAttrs {
+		attr := resp.Schema.Attributes[attrName]
+		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
+	}
+
+	// Verify sensitive attributes
+	sensitiveAttrs := []string{"content", "password", "url", "delete_token"}
+	for _, attrName := range sensitiveAttrs {
+		attr := resp.Schema.Attributes[attrName]
+		assert.True(t, attr.IsSensitive(), "Attribute %s should be sensitive", attrName)
+	}
+}
+
+func TestPasteEphemeralResource_Configure_Success(t *testing.T) {
+	r := &PasteEphemeralResource{}
+	ctx := context.Background()
+
+	providerData := createMockProviderData()
+	req := ephemeral.ConfigureRequest{
+		ProviderData: providerData,
+	}
+	resp := &ephemeral.ConfigureResponse{}
+
+	r.Configure(ctx, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, providerData, r.providerData)
+}
+
+func TestPasteEphemeralResource_Configure_InvalidProviderData(t *testing.T) {
+	r := &PasteEphemeralResource{}
+	ctx := context.Background()
+
+	req := ephemeral.ConfigureRequest{
+		ProviderData: "invalid", // Wrong type
+	}
+	resp := &ephemeral.ConfigureResponse{}
+
+	r.Configure(ctx, req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unexpected Ephemeral Resource Configure Type")
+}
+
+func TestPasteEphemeralResource_Configure_NilProviderData(t *testing.T) {
+	r := &PasteEphemeralResource{}
+	ctx := context.Background()
+
+	req := ephemeral.ConfigureRequest{
+		ProviderData: nil,
+	}
+	resp := &ephemeral.ConfigureResponse{}
+
+	r.Configure(ctx, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Nil(t, r.providerData)
+}
+
+func TestNewPasteEphemeralResource(t *testing.T) {
+	r := NewPasteEphemeralResource()
+	assert.NotNil(t, r)
+
+	// Verify it's the correct type
+	_, ok := r.(*PasteEphemeralResource)
+	assert.True(t, ok)
+}
+
+func TestPasteEphemeralResource_Close(t *testing.T) {
+	tests := []struct {
+		name             string
+		burnAfterReading bool
+	}{
+		{name: "paste", burnAfterReading: false},
+		{name: "burn after reading paste", burnAfterReading: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakePrivateBin(t)
+			ctx := context.Background()
+			server := testEphemeralServer(t, fake)
+
+			openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+				TypeName: "pastebin_paste",
+				Config: testEphemeralConfig(t, map[string]tftypes.Value{
+					"content":            tftypes.NewValue(tftypes.String, "one-time secret"),
+					"expire":             tftypes.NewValue(tftypes.String, "1day"),
+					"burn_after_reading": tftypes.NewValue(tftypes.Bool, tt.burnAfterReading),
+					"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+						"X-Tenant": tftypes.NewValue(tftypes.String, "blue"),
+					}),
+				}),
+			})
+			require.NoError(t, err)
+			require.Empty(t, openResp.Diagnostics)
+
+			requests := fake.createRequests()
+			require.Len(t, requests, 1)
+			assert.Equal(t, "blue", requests[0].Header.Get("X-Tenant"))
+			require.True(t, fake.hasPaste(fmt.Sprintf("%016x", 1)))
+
+			closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+				TypeName: "pastebin_paste",
+				Private:  openResp.Private,
+			})
+			require.NoError(t, err)
+			assert.Empty(t, closeResp.Diagnostics)
+
+			// The paste is deleted without being read
+			assert.False(t, fake.hasPaste(fmt.Sprintf("%016x", 1)))
+			fake.mu.Lock()
+			deleteRequest := fake.requests[len(fake.requests)-1]
+			fake.mu.Unlock()
+			assert.Equal(t, "blue", deleteRequest.Header.Get("X-Tenant"))
+		})
+	}
+}
+
+func TestPasteEphemeralResource_Close_AlreadyDeleted(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	ctx := context.Background()
+	server := testEphemeralServer(t, fake)
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "pastebin_paste",
+		Config: testEphemeralConfig(t, map[string]tftypes.Value{
+			"content": tftypes.NewValue(tftypes.String, "one-time secret"),
+		}),
+	})
+	require.NoError(t, err)
+	require.Empty(t, openResp.Diagnostics)
+
+	// Burned or expired before Terraform closed it
+	fake.deletePaste(fmt.Sprintf("%016x", 1))
+
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "pastebin_paste",
+		Private:  openResp.Private,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, closeResp.Diagnostics)
+}
+
+func TestPasteEphemeralResource_Open_BinaryContent(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	ctx := context.Background()
+	server := testEphemeralServer(t, fake)
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "pastebin_paste",
+		Config: testEphemeralConfig(t, map[string]tftypes.Value{
+			"content": tftypes.NewValue(tftypes.String, "binary\x00data"),
+		}),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, openResp.Diagnostics, 1)
+	assert.Equal(t, "Binary Paste Content", openResp.Diagnostics[0].Summary)
+	assert.Empty(t, fake.createRequests())
+}
+
+func TestPasteEphemeralResource_Open_AttachmentFormatter(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	ctx := context.Background()
+	server := testEphemeralServer(t, fake, map[string]tftypes.Value{
+		"formatter": tftypes.NewValue(tftypes.String, "markdown"),
+	})
+
+	for _, values := range []map[string]tftypes.Value{
+		{"content": tftypes.NewValue(tftypes.String, "# notes")},
+		{"content": tftypes.NewValue(tftypes.String, "report"), "attachment_name": tftypes.NewValue(tftypes.String, "report.txt")},
+	} {
+		openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+			TypeName: "pastebin_paste",
+			Config:   testEphemeralConfig(t, values),
+		})
+		require.NoError(t, err)
+		require.Empty(t, openResp.Diagnostics)
+	}
+
+	// Text pastes get the provider formatter, attachments plaintext
+	requests := fake.createRequests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "markdown", requests[0].Formatter)
+	assert.Equal(t, "plaintext", requests[1].Formatter)
+}
+
+func TestPasteEphemeralResource_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		expected []string
+	}{
+		{
+			name: "valid",
+			values: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "secret"),
+			},
+		},
+		{
+			name: "empty content",
+			values: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, ""),
+			},
+			expected: []string{"Empty Paste Content"},
+		},
+		{
+			name: "empty attachment",
+			values: map[string]tftypes.Value{
+				"content":         tftypes.NewValue(tftypes.String, ""),
+				"attachment_name": tftypes.NewValue(tftypes.String, "empty.txt"),
+			},
+		},
+		{
+			name: "Accept-Encoding header",
+			values: map[string]tftypes.Value{
+				"content": tftypes.NewValue(tftypes.String, "secret"),
+				"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"accept-encoding": tftypes.NewValue(tftypes.String, "identity"),
+				}),
+			},
+			expected: []string{"Unsupported Extra Header"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &PasteEphemeralResource{}
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testEphemeralValue(t, tt.values)}
+
+			resp := &ephemeral.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, ephemeral.ValidateConfigRequest{Config: config}, resp)
+
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			assert.Equal(t, tt.expected, summaries)
+		})
+	}
+}
+
+// testEphemeralServer returns a provider server configured against fake,
+// with the given extra provider attributes.
+func testEphemeralServer(t *testing.T, fake *fakePrivateBin, values ...map[string]tftypes.Value) tfprotov6.ProviderServer {
+	t.Helper()
+
+	server, err := testProviderFactory()["pastebin"]()
+	require.NoError(t, err)
+
+	config := map[string]tftypes.Value{
+		"host":                tftypes.NewValue(tftypes.String, fake.URL),
+		"allow_insecure_http": tftypes.NewValue(tftypes.Bool, true),
+	}
+	for _, v := range values {
+		for name, value := range v {
+			config[name] = value
+		}
+	}
+	providerConfig := testProviderConfig(t, config)
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, providerConfig.Raw),
+	})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+
+	return server
+}
+
+// testEphemeralValue builds a configuration value of the ephemeral
+// resource, with every attribute not in values null.
+func testEphemeralValue(t *testing.T, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	(&PasteEphemeralResource{}).Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		attrs[name] = value
+	}
+
+	return tftypes.NewValue(objectType, attrs)
+}
+
+// testEphemeralConfig encodes an ephemeral resource configuration for a
+// protocol request.
+func testEphemeralConfig(t *testing.T, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	return testDynamicValue(t, testEphemeralValue(t, values))
+}
+
+// testDynamicValue encodes value for a protocol request.
+func testDynamicValue(t *testing.T, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	require.NoError(t, err)
+
+	return &dv
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PasteExistsDataSource{}
+
+func NewPasteExistsDataSource() datasource.DataSource {
+	return &PasteExistsDataSource{}
+}
+
+// PasteExistsDataSource defines the data source implementation.
+type PasteExistsDataSource struct {
+	providerData *ProviderData
+}
+
+// PasteExistsDataSourceModel describes the data source data model.
+type PasteExistsDataSourceModel struct {
+	URL          types.String `tfsdk:"url"`
+	Password     types.String `tfsdk:"password"`
+	Exists       types.Bool   `tfsdk:"exists"`
+	CommentCount types.Int64  `tfsdk:"comment_count"`
+}
+
+func (d *PasteExistsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_paste_exists"
+}
+
+func (d *PasteExistsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a paste exists, without failing when it does not. " +
+			"Burn-after-reading pastes and pastes the password cannot decrypt are reported as existing without being decrypted, " +
+			"with a comment count of 0.",
+
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "Full URL of the paste including master key",
+				Required:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password to decrypt the paste (if password protected)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether the paste exists on the instance",
+				Computed:            true,
+			},
+			"comment_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of comments on the paste, 0 when it does not exist",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PasteExistsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *PasteExistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PasteExistsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Parse the paste URL
+	pasteURL, err := url.Parse(data.URL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse paste URL: %s", redactError(err)))
+		return
+	}
+
+	options := pastebin.ShowPasteOptions{
+		Password:    []byte(data.Password.ValueString()),
+		ConfirmBurn: false,
+	}
+
+	data.Exists = types.BoolValue(true)
+	data.CommentCount = types.Int64Value(0)
+
+	result, err := callWithFailover(ctx, d.providerData.hostClients(nil), opShowPaste, (*pastebin.Client).ShowPaste, *pasteURL, options, d.providerData.movePasteURL)
+	if err != nil {
+		// Only a missing paste is an answer; an unreachable instance says
+		// nothing about the paste and must not flip conditional resources.
+		if isTransportError(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reach the pastebin instance: %s", d.providerData.redactError(err, data.Password.ValueString())))
+			return
+		}
+
+		switch {
+		// The instance returned the paste, which was not decrypted
+		case isBurnConfirmationError(err), isDecryptionError(err):
+			tflog.Debug(ctx, "paste exists but was not decrypted", map[string]interface{}{
+				"error": d.providerData.redactError(err, data.Password.ValueString()),
+			})
+
+		case isPasteNotFoundError(err):
+			data.Exists = types.BoolValue(false)
+
+		default:
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check the paste: %s", d.providerData.redactError(err, data.Password.ValueString())))
+			return
+		}
+	} else {
+		data.CommentCount = types.Int64Value(int64(result.CommentCount))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
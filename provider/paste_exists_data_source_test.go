@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+func TestPasteExistsDataSource_Metadata(t *testing.T) {
+	d := &PasteExistsDataSource{}
+	ctx := context.Background()
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "pastebin",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(ctx, req, resp)
+
+	assert.Equal(t, "pastebin_paste_exists", resp.TypeName)
+}
+
+func TestPasteExistsDataSource_Schema(t *testing.T) {
+	d := &PasteExistsDataSource{}
+	ctx := context.Background()
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, req, resp)
+
+	require.NotNil(t, resp.Schema.Attributes)
+
+	assert.True(t, resp.Schema.Attributes["url"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["password"].IsSensitive())
+
+	for _, attrName := range []string{"exists", "comment_count"} {
+		attr, exists := resp.Schema.Attributes[attrName]
+		require.True(t, exists, "Expected attribute %s to be present in schema", attrName)
+		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
+	}
+}
+
+func TestPasteExistsDataSource_Configure_InvalidProviderData(t *testing.T) {
+	d := &PasteExistsDataSource{}
+	ctx := context.Background()
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "invalid",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(ctx, req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unexpected Data Source Configure Type")
+}
+
+func TestNewPasteExistsDataSource(t *testing.T) {
+	dataSource := NewPasteExistsDataSource()
+	assert.NotNil(t, dataSource)
+
+	_, ok := dataSource.(*PasteExistsDataSource)
+	assert.True(t, ok)
+}
+
+func TestPasteExistsDataSource_Read(t *testing.T) {
+	fake := newFakePrivateBin(t)
+	providerData := fake.providerData(t, nil)
+	ctx := context.Background()
+
+	create := func(options pastebin.CreatePasteOptions) string {
+		options.Formatter = "plaintext"
+		options.Expire = "1day"
+		result, err := providerData.Client.CreatePaste(ctx, []byte("Hello, World!"), options)
+		require.NoError(t, err)
+		return result.PasteURL.String()
+	}
+	plain := create(pastebin.CreatePasteOptions{})
+	burn := create(pastebin.CreatePasteOptions{BurnAfterReading: true})
+	protected := create(pastebin.CreatePasteOptions{Password: []byte("hunter2")})
+
+	// An instance failing with an error other than a missing paste
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, "Invalid data.")
+	}))
+	t.Cleanup(server.Close)
+	failingData := (&fakePrivateBin{Server: server}).providerData(t, nil)
+
+	tests := []struct {
+		name          string
+		providerData  *ProviderData
+		url           string
+		password      tftypes.Value
+		expected      bool
+		expectedError string
+	}{
+		{name: "existing paste", url: plain, expected: true},
+		{name: "burn after reading paste", url: burn, expected: true},
+		{name: "wrong password", url: protected, password: tftypes.NewValue(tftypes.String, "wrong"), expected: true},
+		{name: "missing paste", url: fake.URL + "/?ffffffffffffffff#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF", expected: false},
+		{name: "other error", providerData: failingData, url: server.URL + "/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF", expectedError: "Client Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &PasteExistsDataSource{providerData: providerData}
+			if tt.providerData != nil {
+				d.providerData = tt.providerData
+			}
+
+			values := map[string]tftypes.Value{"url": tftypes.NewValue(tftypes.String, tt.url)}
+			if !tt.password.IsNull() {
+				values["password"] = tt.password
+			}
+			config := testDataSourceConfig(t, d, values)
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+
+			d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+			if tt.expectedError != "" {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				assert.Equal(t, tt.expectedError, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			var data PasteExistsDataSourceModel
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+			assert.Equal(t, tt.expected, data.Exists.ValueBool())
+		})
+	}
+}
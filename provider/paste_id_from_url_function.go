@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PasteIDFromURLFunction{}
+
+func NewPasteIDFromURLFunction() function.Function {
+	return &PasteIDFromURLFunction{}
+}
+
+// PasteIDFromURLFunction defines the function implementation.
+type PasteIDFromURLFunction struct{}
+
+func (f *PasteIDFromURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "paste_id_from_url"
+}
+
+func (f *PasteIDFromURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Extract the paste ID from a paste URL",
+		MarkdownDescription: "Returns the paste ID of a paste URL. Both the `https://host/?id#key` query form " +
+			"and path based URLs such as `https://host/paste/id#key` are accepted.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "Full URL of the paste",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PasteIDFromURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &rawURL))
+
+	if resp.Error != nil {
+		return
+	}
+
+	_, pasteID, err := parsePasteURL(rawURL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid paste URL: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, pasteID))
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasteIDFromURLFunction_Metadata(t *testing.T) {
+	f := &PasteIDFromURLFunction{}
+	resp := &function.MetadataResponse{}
+
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	assert.Equal(t, "paste_id_from_url", resp.Name)
+}
+
+func TestPasteIDFromURLFunction_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		expectedID  string
+		expectError bool
+	}{
+		{
+			name:       "query form",
+			url:        "https://example.com/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+			expectedID: "abcd1234",
+		},
+		{
+			name:       "path form",
+			url:        "https://example.com/paste/abcd1234#key",
+			expectedID: "abcd1234",
+		},
+		{
+			name:        "relative URL",
+			url:         "abcd1234",
+			expectError: true,
+		},
+		{
+			name:        "URL without paste ID",
+			url:         "https://example.com/#key",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &PasteIDFromURLFunction{}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.url)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.expectError {
+				require.NotNil(t, resp.Error)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				assert.Equal(t, int64(0), *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.StringValue(tt.expectedID), resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PasteIsExpiredFunction{}
+
+func NewPasteIsExpiredFunction() function.Function {
+	return &PasteIsExpiredFunction{}
+}
+
+// PasteIsExpiredFunction defines the function implementation.
+type PasteIsExpiredFunction struct{}
+
+func (f *PasteIsExpiredFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "paste_is_expired"
+}
+
+func (f *PasteIsExpiredFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether a paste has expired",
+		MarkdownDescription: "Returns whether a paste created at `created_at` with the expiration time `expire` " +
+			"has expired by now, without contacting the pastebin instance. Pastes that never expire are never " +
+			"reported as expired. The result depends on the current time, so it can change between plans.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "created_at",
+				MarkdownDescription: "Creation time of the paste in RFC 3339 format, as in the `created_at` attribute of `pastebin_paste`",
+			},
+			function.StringParameter{
+				Name:                "expire",
+				MarkdownDescription: "Expiration time of the paste (" + strings.Join(knownExpirations, ", ") + ")",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *PasteIsExpiredFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var createdAt, expire string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &createdAt, &expire))
+
+	if resp.Error != nil {
+		return
+	}
+
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid creation time: "+err.Error())
+		return
+	}
+
+	if !slices.Contains(knownExpirations, expire) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid expiration time: expected one of %s, got: %q",
+			strings.Join(knownExpirations, ", "), expire))
+		return
+	}
+
+	expired := false
+	if d, ok := expireDuration(expire); ok {
+		expired = !time.Now().Before(created.Add(d))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, expired))
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasteIsExpiredFunction_Metadata(t *testing.T) {
+	f := &PasteIsExpiredFunction{}
+	resp := &function.MetadataResponse{}
+
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	assert.Equal(t, "paste_is_expired", resp.Name)
+}
+
+func TestPasteIsExpiredFunction_Run(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name             string
+		createdAt        string
+		expire           string
+		expected         bool
+		expectedArgument int64
+		expectError      bool
+	}{
+		{
+			name:      "expired",
+			createdAt: now.Add(-2 * time.Hour).Format(time.RFC3339),
+			expire:    "1hour",
+			expected:  true,
+		},
+		{
+			name:      "not expired",
+			createdAt: now.Add(-2 * time.Hour).Format(time.RFC3339),
+			expire:    "1day",
+			expected:  false,
+		},
+		{
+			name:      "never expires",
+			createdAt: "2000-01-01T00:00:00Z",
+			expire:    "never",
+			expected:  false,
+		},
+		{
+			name:             "invalid creation time",
+			createdAt:        "yesterday",
+			expire:           "1day",
+			expectedArgument: 0,
+			expectError:      true,
+		},
+		{
+			name:             "unknown expiration time",
+			createdAt:        now.Format(time.RFC3339),
+			expire:           "2weeks",
+			expectedArgument: 1,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &PasteIsExpiredFunction{}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.createdAt),
+					types.StringValue(tt.expire),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.expectError {
+				require.NotNil(t, resp.Error)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				assert.Equal(t, tt.expectedArgument, *resp.Error.FunctionArgument)
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.BoolValue(tt.expected), resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import "time"
+
+// knownFormatters lists the formatters supported by PrivateBin instances.
+var knownFormatters = []string{
+	"plaintext",
+	"markdown",
+	"syntaxhighlighting",
+}
+
+// knownExpirations lists the expiration times supported by PrivateBin
+// instances, shortest first.
+var knownExpirations = []string{
+	"5min",
+	"10min",
+	"1hour",
+	"1day",
+	"1week",
+	"1month",
+	"1year",
+	neverExpire,
+}
+
+// neverExpire is the expiration time of pastes that are kept forever.
+const neverExpire = "never"
+
+// defaultGZip is whether pastes are compressed when neither the paste nor
+// the provider says otherwise.
+const defaultGZip = true
+
+// defaultTimeout is how long a paste operation may take when its timeouts
+// block does not say otherwise.
+const defaultTimeout = 5 * time.Minute
+
+// expireDurations maps each expiration time, except never, to its length
+// as used by PrivateBin.
+var expireDurations = map[string]time.Duration{
+	"5min":   5 * time.Minute,
+	"10min":  10 * time.Minute,
+	"1hour":  time.Hour,
+	"1day":   24 * time.Hour,
+	"1week":  7 * 24 * time.Hour,
+	"1month": 30 * 24 * time.Hour,
+	"1year":  365 * 24 * time.Hour,
+}
+
+// expireDuration returns how long a paste with the given expiration time
+// lives. It returns false for never and for unknown values.
+func expireDuration(expire string) (time.Duration, bool) {
+	d, ok := expireDurations[expire]
+	return d, ok
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireDuration(t *testing.T) {
+	d, ok := expireDuration("1day")
+	assert.True(t, ok)
+	assert.Equal(t, 24*time.Hour, d)
+
+	_, ok = expireDuration("never")
+	assert.False(t, ok)
+
+	_, ok = expireDuration("2days")
+	assert.False(t, ok)
+}
+
+func TestExpireDuration_KnownExpirations(t *testing.T) {
+	for _, expire := range knownExpirations {
+		_, ok := expireDuration(expire)
+		assert.Equal(t, expire != "never", ok, "unexpected result for %q", expire)
+	}
+}
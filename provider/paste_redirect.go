@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// maxRedirects bounds how many redirects are followed when resolving a
+// paste URL.
+const maxRedirects = 10
+
+// newRedirectClient returns an HTTP client for resolving paste URLs that
+// follows at most maxRedirects redirects and never downgrades from https
+// to http.
+func newRedirectClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if via[len(via)-1].URL.Scheme == "https" && req.URL.Scheme != "https" {
+				return errors.New("refusing to follow a redirect from https to http")
+			}
+			return nil
+		},
+	}
+}
+
+// redirectTransport returns a transport using the given TLS configuration,
+// or the default transport when it is nil.
+func redirectTransport(tlsConfig *tls.Config) http.RoundTripper {
+	if tlsConfig == nil {
+		return http.DefaultTransport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
+// resolvePasteURL follows the redirects of pasteURL and returns the URL it
+// finally points to. The fragment holding the master key is never sent to
+// the server, so it is kept unless the last redirect sets its own.
+func resolvePasteURL(ctx context.Context, client *http.Client, pasteURL *url.URL) (*url.URL, error) {
+	resp, err := doResolve(ctx, client, http.MethodHead, pasteURL)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, err = doResolve(ctx, client, http.MethodGet, pasteURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *resp.Request.URL
+	if resolved.Fragment == "" {
+		resolved.Fragment = pasteURL.Fragment
+		resolved.RawFragment = pasteURL.RawFragment
+	}
+
+	return &resolved, nil
+}
+
+// doResolve sends a request to pasteURL following redirects, discarding the
+// response body.
+func doResolve(ctx context.Context, client *http.Client, method string, pasteURL *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, pasteURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
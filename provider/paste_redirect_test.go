@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePasteURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/s/abc", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/?abcd1234", http.StatusFound)
+	})
+	mux.HandleFunc("/s/keyed", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/?abcd1234#otherkey", http.StatusFound)
+	})
+	mux.HandleFunc("/s/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		http.Redirect(w, r, "/?abcd1234", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "keeps the key",
+			path:     "/s/abc#masterkey",
+			expected: "/?abcd1234#masterkey",
+		},
+		{
+			name:     "redirect sets its own key",
+			path:     "/s/keyed#masterkey",
+			expected: "/?abcd1234#otherkey",
+		},
+		{
+			name:     "falls back to GET",
+			path:     "/s/get-only#masterkey",
+			expected: "/?abcd1234#masterkey",
+		},
+		{
+			name:     "no redirect",
+			path:     "/?abcd1234#masterkey",
+			expected: "/?abcd1234#masterkey",
+		},
+		{
+			name:        "redirect loop",
+			path:        "/loop",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pasteURL, err := url.Parse(server.URL + tt.path)
+			require.NoError(t, err)
+
+			resolved, err := resolvePasteURL(context.Background(), newRedirectClient(http.DefaultTransport), pasteURL)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, server.URL+tt.expected, resolved.String())
+		})
+	}
+}
+
+func TestResolvePasteURL_RefusesDowngrade(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer plain.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/?abcd1234", http.StatusFound)
+	}))
+	defer secure.Close()
+
+	pasteURL, err := url.Parse(secure.URL + "/s/abc#masterkey")
+	require.NoError(t, err)
+
+	_, err = resolvePasteURL(context.Background(), newRedirectClient(secure.Client().Transport), pasteURL)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "from https to http")
+}
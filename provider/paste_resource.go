@@ -2,18 +2,40 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/RO-29/pastebin-go-cli"
 )
@@ -21,6 +43,21 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PasteResource{}
 var _ resource.ResourceWithImportState = &PasteResource{}
+var _ resource.ResourceWithValidateConfig = &PasteResource{}
+var _ resource.ResourceWithConfigValidators = &PasteResource{}
+var _ resource.ResourceWithModifyPlan = &PasteResource{}
+
+// privateKeyPasswordWO is the private state key set when the paste was
+// created with a write-only password.
+const privateKeyPasswordWO = "password_wo"
+
+// idempotencyKeyHeader is the header carrying the idempotency_key of a
+// create request.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// privateKeyDefaultPassword is the private state key set when the paste was
+// created with the provider default password.
+const privateKeyDefaultPassword = "default_password"
 
 func NewPasteResource() resource.Resource {
 	return &PasteResource{}
@@ -33,17 +70,39 @@ type PasteResource struct {
 
 // PasteResourceModel describes the resource data model.
 type PasteResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Content          types.String `tfsdk:"content"`
-	AttachmentName   types.String `tfsdk:"attachment_name"`
-	Formatter        types.String `tfsdk:"formatter"`
-	Expire           types.String `tfsdk:"expire"`
-	Password         types.String `tfsdk:"password"`
-	OpenDiscussion   types.Bool   `tfsdk:"open_discussion"`
-	BurnAfterReading types.Bool   `tfsdk:"burn_after_reading"`
-	GZip             types.Bool   `tfsdk:"gzip"`
-	URL              types.String `tfsdk:"url"`
-	DeleteToken      types.String `tfsdk:"delete_token"`
+	ID                types.String   `tfsdk:"id"`
+	PasteID           types.String   `tfsdk:"paste_id"`
+	Content           types.String   `tfsdk:"content"`
+	ContentBase64     types.String   `tfsdk:"content_base64"`
+	ContentTemplate   types.String   `tfsdk:"content_template"`
+	TemplateVars      types.Map      `tfsdk:"template_vars"`
+	ContentDir        types.String   `tfsdk:"content_dir"`
+	ContentURL        types.String   `tfsdk:"content_url"`
+	AttachmentName    types.String   `tfsdk:"attachment_name"`
+	Formatter         types.String   `tfsdk:"formatter"`
+	Expire            types.String   `tfsdk:"expire"`
+	Password          types.String   `tfsdk:"password"`
+	PasswordWO        types.String   `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64    `tfsdk:"password_wo_version"`
+	OpenDiscussion    types.Bool     `tfsdk:"open_discussion"`
+	BurnAfterReading  types.Bool     `tfsdk:"burn_after_reading"`
+	GZip              types.Bool     `tfsdk:"gzip"`
+	Compressed        types.Bool     `tfsdk:"compressed"`
+	ExtraHeaders      types.Map      `tfsdk:"extra_headers"`
+	URL               types.String   `tfsdk:"url"`
+	DeleteToken       types.String   `tfsdk:"delete_token"`
+	DeleteURL         types.String   `tfsdk:"delete_url"`
+	CreatedAt         types.String   `tfsdk:"created_at"`
+	ContentSHA256     types.String   `tfsdk:"content_sha256"`
+	ExpiresAt         types.String   `tfsdk:"expires_at"`
+	SummaryJSON       types.String   `tfsdk:"summary_json"`
+	ValidateOnly      types.Bool     `tfsdk:"validate_only"`
+	IdempotencyKey    types.String   `tfsdk:"idempotency_key"`
+	MasterKey         types.String   `tfsdk:"master_key"`
+	AllowBinary       types.Bool     `tfsdk:"allow_binary_content"`
+	URLOutputPath     types.String   `tfsdk:"url_output_path"`
+	URLOutputKey      types.Bool     `tfsdk:"url_output_include_key"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *PasteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,22 +121,87 @@ func (r *PasteResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"paste_id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "ID the instance assigned to the paste. It currently equals `id`, " +
+					"but unlike `id` it always holds the server-side ID, so prefer it when passing the paste to other tools",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "The content of the paste",
-				Required:            true,
+				MarkdownDescription: "The content of the paste. Must not be empty unless `attachment_name` is set. " +
+					"Exactly one of `content`, `content_base64`, `content_template`, `content_dir` or `content_url` must be set",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("content_base64"),
+						path.MatchRoot("content_template"),
+						path.MatchRoot("content_dir"),
+						path.MatchRoot("content_url"),
+					),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"attachment_name": schema.StringAttribute{
-				MarkdownDescription: "Name for the attachment (makes the paste an attachment)",
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The content of the paste, base64 encoded, for binary data that cannot be written as an HCL string. " +
+					"It is decoded before the paste is created",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_template": schema.StringAttribute{
+				MarkdownDescription: "Path of a Go `text/template` file rendered with `template_vars` to produce the content of the paste. " +
+					"Referencing a variable missing from `template_vars` is an error. The paste is replaced when the rendered content changes",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Variables available to `content_template`, as in `{{ .name }}`",
 				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("content_template")),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_dir": schema.StringAttribute{
+				MarkdownDescription: "Path of a directory uploaded as a gzipped tarball attachment, named after the directory " +
+					"unless `attachment_name` is set. The directory must contain at least one file, and the archive must not exceed 10 MiB. " +
+					"The paste is replaced when the archive changes",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_url": schema.StringAttribute{
+				MarkdownDescription: "HTTP(S) URL whose body becomes the content of the paste. It is fetched when the paste is created and on every plan, " +
+					"and the paste is replaced when the content changes. The content must not exceed 10 MiB, and the fetch times out after 30 seconds",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(contentURLPattern, "must be an http or https URL"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attachment_name": schema.StringAttribute{
+				MarkdownDescription: "Name for the attachment (makes the paste an attachment). Must not be empty. " +
+					"`formatter` defaults to `plaintext`, and another formatter gives a warning. Defaults to `<directory>.tar.gz` with `content_dir`",
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"formatter": schema.StringAttribute{
-				MarkdownDescription: "Text formatter (plaintext, markdown, syntaxhighlighting)",
+				MarkdownDescription: "Text formatter (plaintext, markdown, syntaxhighlighting). Attachments default to plaintext",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("plaintext"),
@@ -86,11 +210,12 @@ func (r *PasteResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"expire": schema.StringAttribute{
-				MarkdownDescription: "Expiration time (5min, 10min, 1hour, 1day, 1week, 1month, 1year, never)",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("1week"),
+				MarkdownDescription: "Expiration time (5min, 10min, 1hour, 1day, 1week, 1month, 1year, never). " +
+					"Defaults to the provider `expire_by_formatter` entry for the formatter, then the provider `expire`, or 1week",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -102,29 +227,107 @@ func (r *PasteResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only password to protect the paste. The value is never stored in state and requires Terraform 1.11 or later. " +
+					"Terraform cannot detect changes to write-only values, so change `password_wo_version` to force replacement of the paste.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`. Changing it forces replacement of the paste",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
 			"open_discussion": schema.BoolAttribute{
-				MarkdownDescription: "Enable discussion/comments on the paste",
+				MarkdownDescription: "Enable discussion/comments on the paste. Defaults to the provider `open_discussion`, or false",
 				Optional:            true,
 				Computed:            true,
-				Default:             booldefault.StaticBool(false),
 				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
 			"burn_after_reading": schema.BoolAttribute{
-				MarkdownDescription: "Delete the paste after first read",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete the paste after first read. Defaults to the provider `burn_after_reading`, or false. " +
+					"Such pastes are not read back on refresh, so Terraform does not notice once they are burned",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
 					boolplanmodifier.RequiresReplace(),
 				},
 			},
 			"gzip": schema.BoolAttribute{
-				MarkdownDescription: "Enable gzip compression",
+				MarkdownDescription: "Enable gzip compression. Defaults to the provider `gzip`, or true, except for attachments " +
+					"that are already compressed, such as archives, images, audio and video. Existing pastes keep their gzip setting",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					skipGZipForCompressedAttachment(),
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Extra HTTP headers to include in requests for this paste. " +
+					"They take precedence over provider headers with the same name. `Accept-Encoding` cannot be set, as gzip is negotiated automatically",
+				Optional: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_binary_content": schema.BoolAttribute{
+				MarkdownDescription: "Allow control characters and invalid UTF-8 in text pastes. " +
+					"By default such content is rejected, since it does not display correctly; attachments are never checked",
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"url_output_path": schema.StringAttribute{
+				MarkdownDescription: "Append the URL of the created paste to this file, one per line. " +
+					"Pastes of one apply are appended safely, but separate Terraform runs must not write to the same file at once",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url_output_include_key": schema.BoolAttribute{
+				MarkdownDescription: "Include the key fragment in the URL written to `url_output_path`, which lets anyone reading the file decrypt the paste",
 				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(true),
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("url_output_path")),
+				},
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"idempotency_key": schema.StringAttribute{
+				MarkdownDescription: "Key sent in the `Idempotency-Key` header when creating the paste, so an instance that supports it " +
+					"returns the existing paste instead of a duplicate when the create is retried, including on another host of `hosts`. " +
+					"Set a stable value to also cover re-running a failed apply. A random key is generated when unset. " +
+					"Instances without support ignore the header",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validate_only": schema.BoolAttribute{
+				MarkdownDescription: "Only run the client-side checks (size, formatter, expiration and compression) without creating the paste. " +
+					"The paste does not exist on the server, and `id`, `url` and `delete_token` stay null",
+				Optional: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplace(),
 				},
@@ -144,10 +347,306 @@ func (r *PasteResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"delete_url": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Link that deletes the paste when opened, built from `url`, `id` and `delete_token`. Null when there is no delete token",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"master_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Key needed to decrypt the paste, taken from the `url` fragment",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"compressed": schema.BoolAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether the content was sent compressed. It differs from `gzip` when the instance rejected the " +
+					"compressed paste and the provider `gzip_fallback` created it uncompressed",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Hex encoded SHA-256 of the uncompressed content. The paste is replaced when the content read back no longer matches",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC3339 timestamp of when the paste was created, taken from the local clock",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "RFC3339 timestamp of when the paste expires, computed from `created_at` and `expire`. " +
+					"Null when `expire` is `never`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"summary_json": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "JSON object with the `id`, `url` without its key, `formatter`, `expire`, `expires_at` and `gzip` of the paste, " +
+					"for passing the paste to other tools. It holds no key or password",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *PasteResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		attachmentConfig(),
+	}
+}
+
+func (r *PasteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data PasteResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Checking the content needs it to be known, and exactly one of content,
+	// content_base64, content_template, content_dir or content_url is
+	// enforced by their validators. content_url is not fetched to validate.
+	if pasteContentKnown(data) && pasteContentSources(data) == 1 && data.ContentURL.IsNull() {
+		content, err := pasteContent(data)
+		if err != nil {
+			addPasteContentError(&resp.Diagnostics, data, err)
+		} else if len(content) == 0 && !data.AttachmentName.IsUnknown() && pasteAttachmentName(data) == "" {
+			// The server rejects empty pastes with an unhelpful error, so
+			// catch them here. An attachment may legitimately be an empty
+			// file.
+			resp.Diagnostics.AddAttributeError(
+				pasteContentPath(data),
+				"Empty Paste Content",
+				"The paste has no content. Set content to the text to share, "+
+					"or set attachment_name to upload content as an attachment.",
+			)
+		}
+	}
+
+	for name := range data.ExtraHeaders.Elements() {
+		if strings.EqualFold(name, acceptEncodingHeader) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers").AtMapKey(name),
+				"Unsupported Extra Header",
+				acceptEncodingDetail,
+			)
+		}
+	}
+
+	// A resource is expected to outlive many plans, while a burn paste is
+	// gone after its first read, and Terraform cannot notice that.
+	if data.BurnAfterReading.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("burn_after_reading"),
+			"Burn-After-Reading Paste Managed as a Resource",
+			"The paste is deleted by the server the first time it is read, but Terraform cannot detect that and keeps it in state. "+
+				"Reading it with the pastebin_paste data source burns it on the next refresh. "+
+				"For one-time secrets, consider the pastebin_paste ephemeral resource instead.",
+		)
+	}
+}
+
+func (r *PasteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PasteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plan the provider defaults for flags the configuration leaves unset,
+	// so the plan shows what the paste is created with. Existing pastes
+	// keep their values through UseStateForUnknown.
+	var providerData ProviderData
+	if r.providerData != nil {
+		providerData = *r.providerData
+	}
+
+	flags := []struct {
+		name            string
+		value           types.Bool
+		providerDefault *bool
+		fallback        bool
+	}{
+		{"gzip", plan.GZip, providerData.GZip, defaultGZip},
+		{"open_discussion", plan.OpenDiscussion, providerData.OpenDiscussion, false},
+		{"burn_after_reading", plan.BurnAfterReading, providerData.BurnAfterReading, false},
+	}
+
+	for _, f := range flags {
+		if !f.value.IsUnknown() {
+			continue
+		}
+
+		var configValue types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(f.name), &configValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if configValue.IsNull() {
+			value := resolveBool(configValue, f.providerDefault, f.fallback)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(f.name), value)...)
+		}
+	}
+
+	// The default expiration depends on the formatter, so it can only be
+	// planned once the formatter is known
+	if r.providerData != nil && plan.Expire.IsUnknown() && !plan.Formatter.IsUnknown() {
+		var configExpire types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expire"), &configExpire)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if configExpire.IsNull() {
+			plan.Expire = types.StringValue(r.providerData.defaultExpire(plan.Formatter.ValueString()))
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expire"), plan.Expire)...)
+		}
+	}
+
+	// Checked here rather than in ValidateConfig, which runs before the
+	// provider is configured
+	if r.providerData != nil && !plan.Expire.IsUnknown() {
+		if err := r.providerData.checkExpire(plan.Expire.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expire"),
+				"Never Expiring Paste Not Allowed",
+				fmt.Sprintf("Unable to plan paste: %s. Set expire to a finite duration such as 1month.", err),
+			)
+			return
+		}
+	}
+
+	// Nothing is orphaned when creating
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state PasteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate-only pastes were never created
+	if state.ValidateOnly.ValueBool() {
+		return
+	}
+
+	replaced := replacedPasteAttributes(state, plan)
+
+	// Terraform only sees the path of a template or directory or the URL of
+	// the content, so compare the content it produces now with the content
+	// of the paste
+	contentFromSource := !plan.ContentTemplate.IsNull() || !plan.ContentDir.IsNull() || !plan.ContentURL.IsNull()
+	if len(replaced) == 0 && contentFromSource && pasteContentKnown(plan) && !state.ContentSHA256.IsNull() {
+		// Render and archive errors are reported by ValidateConfig. A
+		// failed fetch keeps the paste, as there is nothing to compare.
+		if content, err := r.fetchPasteContent(ctx, plan); err != nil {
+			tflog.Warn(ctx, "unable to check the paste content for changes", map[string]interface{}{
+				"paste_id": state.ID.ValueString(),
+				"error":    err.Error(),
+			})
+		} else if sha := contentSHA256(content); sha != state.ContentSHA256.ValueString() {
+			contentPath := pasteContentPath(plan)
+			tflog.Debug(ctx, "content source changed", map[string]interface{}{
+				"paste_id":        state.ID.ValueString(),
+				"attribute":       contentPath.String(),
+				"expected_sha256": state.ContentSHA256.ValueString(),
+				"actual_sha256":   sha,
+			})
+
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_sha256"), sha)...)
+			resp.RequiresReplace = append(resp.RequiresReplace, contentPath)
+			replaced = append(replaced, contentPath.String())
+		}
+	}
+
+	if len(replaced) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Previous Paste Remains Accessible",
+		fmt.Sprintf("Paste %s will be replaced, but pastes cannot be deleted yet. "+
+			"Anyone with its URL can read the previous paste until it expires or is burned.", state.ID.ValueString()),
+	)
+}
+
+// replacedPasteAttributes returns the arguments whose planned change
+// replaces the paste. Every argument of the resource forces replacement.
+func replacedPasteAttributes(state, plan PasteResourceModel) []string {
+	arguments := []struct {
+		name        string
+		state, plan attr.Value
+	}{
+		{"content", state.Content, plan.Content},
+		{"content_base64", state.ContentBase64, plan.ContentBase64},
+		{"content_template", state.ContentTemplate, plan.ContentTemplate},
+		{"template_vars", state.TemplateVars, plan.TemplateVars},
+		{"content_dir", state.ContentDir, plan.ContentDir},
+		{"content_url", state.ContentURL, plan.ContentURL},
+		{"attachment_name", state.AttachmentName, plan.AttachmentName},
+		{"formatter", state.Formatter, plan.Formatter},
+		{"expire", state.Expire, plan.Expire},
+		{"password", state.Password, plan.Password},
+		{"password_wo_version", state.PasswordWOVersion, plan.PasswordWOVersion},
+		{"open_discussion", state.OpenDiscussion, plan.OpenDiscussion},
+		{"burn_after_reading", state.BurnAfterReading, plan.BurnAfterReading},
+		{"gzip", state.GZip, plan.GZip},
+		{"extra_headers", state.ExtraHeaders, plan.ExtraHeaders},
+		{"validate_only", state.ValidateOnly, plan.ValidateOnly},
+		{"idempotency_key", state.IdempotencyKey, plan.IdempotencyKey},
+		{"allow_binary_content", state.AllowBinary, plan.AllowBinary},
+		{"url_output_path", state.URLOutputPath, plan.URLOutputPath},
+		{"url_output_include_key", state.URLOutputKey, plan.URLOutputKey},
+	}
+
+	var replaced []string
+	for _, a := range arguments {
+		if !a.state.Equal(a.plan) {
+			replaced = append(replaced, a.name)
+		}
+	}
+
+	return replaced
+}
+
 func (r *PasteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -178,31 +677,32 @@ func (r *PasteResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Use provider defaults if not specified
 	formatter := data.Formatter.ValueString()
 	if formatter == "" {
 		formatter = r.providerData.Formatter
+		if pasteAttachmentName(data) != "" {
+			formatter = attachmentFormatter
+		}
 	}
 
 	expire := data.Expire.ValueString()
 	if expire == "" {
-		expire = r.providerData.Expire
-	}
-
-	gzip := data.GZip.ValueBool()
-	if data.GZip.IsNull() {
-		gzip = r.providerData.GZip
+		expire = r.providerData.defaultExpire(formatter)
 	}
 
-	openDiscussion := data.OpenDiscussion.ValueBool()
-	if data.OpenDiscussion.IsNull() {
-		openDiscussion = r.providerData.OpenDiscussion
-	}
-
-	burnAfterReading := data.BurnAfterReading.ValueBool()
-	if data.BurnAfterReading.IsNull() {
-		burnAfterReading = r.providerData.BurnAfterReading
-	}
+	gzip := resolveBool(data.GZip, r.providerData.GZip, defaultGZip)
+	openDiscussion := resolveBool(data.OpenDiscussion, r.providerData.OpenDiscussion, false)
+	burnAfterReading := resolveBool(data.BurnAfterReading, r.providerData.BurnAfterReading, false)
 
 	// Prepare paste options
 	compress := pastebin.CompressionAlgorithmNone
@@ -212,8 +712,24 @@ func (r *PasteResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	password := []byte(data.Password.ValueString())
 
+	// Write-only attributes are always null in the plan, so read the
+	// password from the configuration instead.
+	var passwordWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !passwordWO.IsNull() {
+		password = []byte(passwordWO.ValueString())
+	}
+
+	useDefaultPassword := data.Password.IsNull() && passwordWO.IsNull() && r.providerData.DefaultPassword != ""
+	if useDefaultPassword {
+		password = []byte(r.providerData.DefaultPassword)
+	}
+
 	options := pastebin.CreatePasteOptions{
-		AttachmentName:   data.AttachmentName.ValueString(),
+		AttachmentName:   pasteAttachmentName(data),
 		Formatter:        formatter,
 		Expire:           expire,
 		OpenDiscussion:   openDiscussion,
@@ -222,27 +738,198 @@ func (r *PasteResource) Create(ctx context.Context, req resource.CreateRequest,
 		Password:         password,
 	}
 
-	// Create the paste
-	result, err := r.providerData.Client.CreatePaste(ctx, []byte(data.Content.ValueString()), options)
+	content, err := r.fetchPasteContent(ctx, data)
+	if err != nil {
+		addPasteContentError(&resp.Diagnostics, data, err)
+		return
+	}
+
+	// Text pastes are rendered in the browser, where binary data shows up
+	// garbled or truncated
+	if pasteAttachmentName(data) == "" && !data.AllowBinary.ValueBool() {
+		if err := checkTextContent(content); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				pasteContentPath(data),
+				"Binary Paste Content",
+				fmt.Sprintf("Unable to create paste: %s. Set attachment_name to upload the content as an attachment, "+
+					"or set allow_binary_content = true to create the text paste anyway.", err),
+			)
+			return
+		}
+	}
+
+	// Fail before contacting the server if the paste is over the limit
+	if err := r.providerData.checkPasteSize(len(content)); err != nil {
+		resp.Diagnostics.AddAttributeError(pasteContentPath(data), "Paste Too Large", fmt.Sprintf("Unable to create paste: %s", err))
+		return
+	}
+
+	if data.ValidateOnly.ValueBool() {
+		if !slices.Contains(knownFormatters, formatter) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("formatter"),
+				"Invalid Formatter",
+				fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownFormatters, ", "), formatter),
+			)
+		}
+
+		if !slices.Contains(knownExpirations, expire) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expire"),
+				"Invalid Expiration",
+				fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownExpirations, ", "), expire),
+			)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("validate_only"),
+			"Paste Not Created",
+			"The paste passed validation but was not created because validate_only is set. "+
+				"Remove validate_only to create it.",
+		)
+
+		data.ID = types.StringNull()
+		data.PasteID = types.StringNull()
+		data.URL = types.StringNull()
+		data.DeleteToken = types.StringNull()
+		data.DeleteURL = types.StringNull()
+		data.MasterKey = types.StringNull()
+		data.CreatedAt = types.StringNull()
+		data.ExpiresAt = types.StringNull()
+		data.SummaryJSON = types.StringNull()
+		data.Compressed = types.BoolNull()
+		if data.IdempotencyKey.IsUnknown() {
+			data.IdempotencyKey = types.StringNull()
+		}
+		data.ContentSHA256 = types.StringValue(contentSHA256(content))
+		data.Formatter = types.StringValue(formatter)
+		data.Expire = types.StringValue(expire)
+		data.GZip = types.BoolValue(gzip)
+		data.OpenDiscussion = types.BoolValue(openDiscussion)
+		data.BurnAfterReading = types.BoolValue(burnAfterReading)
+
+		tflog.Info(ctx, "validate_only is set, skipping paste creation")
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Create the paste. ElementsAs sets a null map to nil, and the
+	// idempotency key is added below.
+	headers := make(map[string]string)
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every host tried for this create gets the same key
+	if data.IdempotencyKey.IsUnknown() || data.IdempotencyKey.IsNull() {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to generate idempotency key: %s", err))
+			return
+		}
+		data.IdempotencyKey = types.StringValue(key)
+	}
+	headers[idempotencyKeyHeader] = data.IdempotencyKey.ValueString()
+
+	secrets := append(sensitiveHeaderValues(headers), string(password))
+	clients := r.providerData.hostClients(headers)
+
+	createdAt := time.Now()
+	result, err := callWithFailover(ctx, clients, opCreatePaste, (*pastebin.Client).CreatePaste, content, options, nil)
+	if err != nil && r.providerData.retryUncompressed(ctx, options, err, secrets...) {
+		options.Compress = pastebin.CompressionAlgorithmNone
+		result, err = callWithFailover(ctx, clients, opCreatePaste, (*pastebin.Client).CreatePaste, content, options, nil)
+	}
+	duration := time.Since(createdAt)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create paste, got error: %s", err))
+		tflog.Debug(ctx, "paste creation failed", map[string]interface{}{
+			"duration_ms": duration.Milliseconds(),
+		})
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create paste, got error: %s", r.providerData.redactError(err, secrets...)))
 		return
 	}
 
 	// Save data into Terraform state
 	data.ID = types.StringValue(result.PasteID)
+	data.PasteID = types.StringValue(result.PasteID)
 	data.URL = types.StringValue(result.PasteURL.String())
 	data.DeleteToken = types.StringValue(result.DeleteToken)
+	data.DeleteURL = types.StringNull()
+	if deleteURL := pasteDeleteURL(result.PasteURL, result.PasteID, result.DeleteToken); deleteURL != "" {
+		data.DeleteURL = types.StringValue(deleteURL)
+	}
+	data.MasterKey = types.StringValue(result.PasteURL.Fragment)
+	data.CreatedAt = types.StringValue(createdAt.UTC().Format(time.RFC3339))
+	data.ContentSHA256 = types.StringValue(contentSHA256(content))
+
+	data.ExpiresAt = types.StringNull()
+	if d, ok := expireDuration(expire); ok {
+		data.ExpiresAt = types.StringValue(createdAt.Add(d).UTC().Format(time.RFC3339))
+	}
+
+	// The client returns neither the response headers nor a creation time,
+	// so expires_at can be off by the skew between this clock and the
+	// server's
+	tflog.Debug(ctx, "computed paste creation time from the local clock", map[string]interface{}{
+		"created_at":        data.CreatedAt.ValueString(),
+		"created_at_source": "local_clock",
+	})
 
 	// Set computed values based on what was actually used
 	data.Formatter = types.StringValue(formatter)
 	data.Expire = types.StringValue(expire)
 	data.GZip = types.BoolValue(gzip)
+	data.Compressed = types.BoolValue(options.Compress != pastebin.CompressionAlgorithmNone)
 	data.OpenDiscussion = types.BoolValue(openDiscussion)
 	data.BurnAfterReading = types.BoolValue(burnAfterReading)
 
+	summary, err := pasteSummaryJSON(data, result.PasteURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode paste summary: %s", err))
+		return
+	}
+	data.SummaryJSON = types.StringValue(summary)
+
+	// Remember that the password is not available in state, so Read does
+	// not treat the paste as gone when it cannot decrypt it.
+	if !passwordWO.IsNull() {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyPasswordWO, []byte(`true`))...)
+	}
+
+	// The provider default password is not stored in state either
+	if useDefaultPassword {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyDefaultPassword, []byte(`true`))...)
+	}
+
+	// The paste exists at this point, so a failed write only warns
+	if !data.URLOutputPath.IsNull() {
+		outputURL := result.PasteURL
+		if !data.URLOutputKey.ValueBool() {
+			outputURL.Fragment = ""
+		}
+
+		if err := appendLine(data.URLOutputPath.ValueString(), outputURL.String()); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("url_output_path"),
+				"Unable to Write Paste URL",
+				fmt.Sprintf("The paste was created, but its URL could not be appended to %s: %s", data.URLOutputPath.ValueString(), err),
+			)
+		}
+	}
+
 	// Write logs using the tflog package
-	// tflog.Trace(ctx, "created a paste resource")
+	tflog.Trace(ctx, "created a paste resource", map[string]interface{}{
+		"paste_id":    result.PasteID,
+		"duration_ms": duration.Milliseconds(),
+	})
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -258,34 +945,161 @@ func (r *PasteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// States written before paste_id existed only hold id. Set it before
+	// any early return keeps the prior state.
+	if data.PasteID.IsNull() && !data.ID.IsNull() {
+		data.PasteID = data.ID
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("paste_id"), data.PasteID)...)
+	}
+
+	// Pastes protected by a write-only password cannot be decrypted
+	// outside of apply, so keep the prior state as is.
+	passwordWO, diags := req.Private.GetKey(ctx, privateKeyPasswordWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if passwordWO != nil {
+		return
+	}
+
+	// Validate-only pastes were never created
+	if data.ValidateOnly.ValueBool() {
+		return
+	}
+
+	// Reading a burn-after-reading paste without confirming the burn cannot
+	// tell whether it still exists, and confirming would delete it. Keep the
+	// prior state so the resource stays stable until it is replaced.
+	if data.BurnAfterReading.ValueBool() {
+		tflog.Debug(ctx, "skipping read of burn-after-reading paste", map[string]interface{}{
+			"paste_id": data.ID.ValueString(),
+		})
+		return
+	}
+
 	// Parse the URL to check if paste still exists
-	pasteURL, err := url.Parse(data.URL.ValueString())
+	pasteURL, err := statePasteURL(data.URL.ValueString(), data.MasterKey.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse paste URL: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse paste URL: %s", redactError(err)))
+		return
+	}
+
+	password := []byte(data.Password.ValueString())
+
+	defaultPassword, diags := req.Private.GetKey(ctx, privateKeyDefaultPassword)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	if defaultPassword != nil {
+		password = []byte(r.providerData.DefaultPassword)
+	}
 
 	// Try to read the paste (this will fail if it doesn't exist or was burned)
 	options := pastebin.ShowPasteOptions{
-		Password:    []byte(data.Password.ValueString()),
+		Password:    password,
 		ConfirmBurn: false, // Don't actually read burn-after-reading pastes
 	}
 
-	_, err = r.providerData.Client.ShowPaste(ctx, *pasteURL, options)
+	headers := make(map[string]string)
+	resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := callWithFailover(ctx, r.providerData.hostClients(headers), opShowPaste, (*pastebin.Client).ShowPaste, *pasteURL, options, r.providerData.movePasteURL)
 	if err != nil {
+		// A cancelled or failed request says nothing about the paste, so
+		// keep it in state
+		if isTransportError(err) {
+			secrets := append(sensitiveHeaderValues(headers), string(password))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reach the pastebin instance: %s", r.providerData.redactError(err, secrets...)))
+			return
+		}
+
+		// The instance returned the paste, so it still exists, but the
+		// password or key in state cannot open it. Removing it would
+		// orphan the paste and plan a duplicate.
+		if isDecryptionError(err) {
+			tflog.Warn(ctx, "paste could not be decrypted, keeping it in state", map[string]interface{}{
+				"paste_id": data.ID.ValueString(),
+			})
+
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("password"),
+				"Paste Could Not Be Decrypted",
+				fmt.Sprintf("Paste %s still exists, but could not be decrypted with the password and key in state, "+
+					"so changes made outside of Terraform cannot be detected. Check password, or the default_password of the provider "+
+					"if the paste was created with it.", data.ID.ValueString()),
+			)
+			return
+		}
+
 		// If we can't read the paste, it might have been deleted or burned
 		// Remove from state
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// Detect content modified outside of Terraform. Storing the remote
+	// content makes the plan differ from the configuration, which replaces
+	// the paste.
+	remoteContent := result.Paste.Data
+	if result.Paste.AttachmentName != "" {
+		remoteContent = result.Paste.Attachement
+	}
+
+	if remoteSHA256 := contentSHA256(remoteContent); !data.ContentSHA256.IsNull() && remoteSHA256 != data.ContentSHA256.ValueString() {
+		tflog.Warn(ctx, "paste content changed outside of Terraform", map[string]interface{}{
+			"paste_id":        data.ID.ValueString(),
+			"expected_sha256": data.ContentSHA256.ValueString(),
+			"actual_sha256":   remoteSHA256,
+		})
+
+		// Templates, directories and URLs are not stored in state, so
+		// ModifyPlan detects the change from content_sha256 alone
+		switch {
+		case !data.ContentBase64.IsNull():
+			data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(remoteContent))
+		case data.ContentTemplate.IsNull() && data.ContentDir.IsNull() && data.ContentURL.IsNull():
+			data.Content = types.StringValue(string(remoteContent))
+		}
+		data.ContentSHA256 = types.StringValue(remoteSHA256)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *PasteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Pastes are immutable, so any changes require replacement
-	// This should not be called due to RequiresReplace plan modifiers
+	// Pastes are immutable, so any changes to arguments require
+	// replacement. Only the timeouts can change in place.
+	var plan, state PasteResourceModel
+
+	if !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if len(replacedPasteAttributes(state, plan)) == 0 {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
 		"Paste resources are immutable and cannot be updated. Any changes require replacement.",
@@ -315,5 +1129,276 @@ func (r *PasteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *PasteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	pasteURL, pasteID, err := parsePasteURL(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a full paste URL like https://host/?id#key: %s", redactError(err)),
+		)
+		return
+	}
+
+	// Pastes are encrypted with the key in the fragment, so without it the
+	// paste could never be read back
+	if pasteURL.Fragment == "" {
+		resp.Diagnostics.AddError(
+			"Missing Paste Key",
+			"The import ID must be the full paste URL including its #key fragment, like https://host/?id#key. "+
+				"Pastes are encrypted with that key, so without it the paste cannot be read.",
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// Read the paste now, so a wrong ID or key fails the import rather
+	// than every later plan
+	result, err := callWithFailover(ctx, r.providerData.hostClients(nil), opShowPaste, (*pastebin.Client).ShowPaste, *pasteURL, pastebin.ShowPasteOptions{}, r.providerData.movePasteURL)
+	switch {
+	case err == nil:
+		content := result.Paste.Data
+		if result.Paste.AttachmentName != "" {
+			content = result.Paste.Attachement
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attachment_name"), result.Paste.AttachmentName)...)
+		}
+
+		// Changing the content attribute replaces the paste, so attachments
+		// go into the one a configuration would use: content for text and
+		// content_base64 for anything else
+		if result.Paste.AttachmentName == "" || checkTextContent(content) == nil {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content"), string(content))...)
+		} else {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_base64"), base64.StdEncoding.EncodeToString(content))...)
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_sha256"), contentSHA256(content))...)
+
+	// The instance returned the paste, but it needs the password from the
+	// configuration, which import cannot see
+	case isDecryptionError(err):
+		resp.Diagnostics.AddWarning(
+			"Paste Not Verified",
+			fmt.Sprintf("Paste %s exists but is password protected, so its content was not read. "+
+				"Set password in the configuration so later plans can read it.", pasteID),
+		)
+
+	// Reading a burn-after-reading paste would delete it
+	case isBurnConfirmationError(err):
+		resp.Diagnostics.AddWarning(
+			"Paste Not Verified",
+			fmt.Sprintf("Paste %s is deleted after it is read, so it was imported without reading it. "+
+				"Set burn_after_reading = true in the configuration.", pasteID),
+		)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("burn_after_reading"), true)...)
+
+	default:
+		resp.Diagnostics.AddError(
+			"Paste Not Readable",
+			fmt.Sprintf("Paste %s could not be read, so it was not imported. It may not exist, may have expired, "+
+				"or the key may be wrong: %s", pasteID, r.providerData.redactError(err)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pasteID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("paste_id"), pasteID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("url"), pasteURL.String())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("master_key"), pasteURL.Fragment)...)
+}
+
+// pasteContent returns the content of the paste, decoding content_base64,
+// rendering content_template or archiving content_dir when it is set. It
+// does not fetch content_url, see fetchPasteContent.
+func pasteContent(data PasteResourceModel) ([]byte, error) {
+	if !data.ContentBase64.IsNull() {
+		return base64.StdEncoding.DecodeString(data.ContentBase64.ValueString())
+	}
+
+	if !data.ContentDir.IsNull() {
+		return archiveContentDir(data.ContentDir.ValueString())
+	}
+
+	if !data.ContentTemplate.IsNull() {
+		vars, err := templateVars(data.TemplateVars)
+		if err != nil {
+			return nil, err
+		}
+
+		return renderContentTemplate(data.ContentTemplate.ValueString(), vars)
+	}
+
+	return []byte(data.Content.ValueString()), nil
+}
+
+// pasteContentSources returns how many of content, content_base64,
+// content_template, content_dir and content_url are set.
+func pasteContentSources(data PasteResourceModel) int {
+	sources := 0
+	for _, source := range []types.String{data.Content, data.ContentBase64, data.ContentTemplate, data.ContentDir, data.ContentURL} {
+		if !source.IsNull() {
+			sources++
+		}
+	}
+
+	return sources
+}
+
+// addPasteContentError reports an error returned by pasteContent on the
+// attribute it comes from.
+func addPasteContentError(diags *diag.Diagnostics, data PasteResourceModel, err error) {
+	if !data.ContentURL.IsNull() {
+		diags.AddAttributeError(
+			path.Root("content_url"),
+			"Unable to Fetch Content URL",
+			fmt.Sprintf("content_url %s could not be fetched: %s", data.ContentURL.ValueString(), err),
+		)
+		return
+	}
+
+	if !data.ContentDir.IsNull() {
+		diags.AddAttributeError(
+			path.Root("content_dir"),
+			"Unable to Archive Content Directory",
+			fmt.Sprintf("content_dir %s could not be archived: %s", data.ContentDir.ValueString(), err),
+		)
+		return
+	}
+
+	if !data.ContentTemplate.IsNull() {
+		diags.AddAttributeError(
+			path.Root("content_template"),
+			"Unable to Render Content Template",
+			fmt.Sprintf("content_template %s could not be rendered: %s", data.ContentTemplate.ValueString(), err),
+		)
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("content_base64"),
+		"Invalid Base64 Content",
+		fmt.Sprintf("content_base64 must be standard base64 encoded: %s", err),
+	)
+}
+
+// pasteContentPath returns the path of the attribute holding the content
+// of the paste.
+func pasteContentPath(data PasteResourceModel) path.Path {
+	if !data.ContentBase64.IsNull() {
+		return path.Root("content_base64")
+	}
+
+	if !data.ContentTemplate.IsNull() {
+		return path.Root("content_template")
+	}
+
+	if !data.ContentDir.IsNull() {
+		return path.Root("content_dir")
+	}
+
+	if !data.ContentURL.IsNull() {
+		return path.Root("content_url")
+	}
+
+	return path.Root("content")
+}
+
+// pasteAttachmentName returns the name the paste is attached under, or ""
+// for text pastes. A content_dir archive is attached under the name of its
+// directory unless attachment_name is set.
+func pasteAttachmentName(data PasteResourceModel) string {
+	if data.AttachmentName.IsNull() && !data.ContentDir.IsNull() && !data.ContentDir.IsUnknown() {
+		return contentDirAttachmentName(data.ContentDir.ValueString())
+	}
+
+	return data.AttachmentName.ValueString()
+}
+
+// pasteSummary is the JSON object stored in summary_json. Its fields are
+// encoded in declaration order, which keeps the value stable.
+type pasteSummary struct {
+	ID        string  `json:"id"`
+	URL       string  `json:"url"`
+	Formatter string  `json:"formatter"`
+	Expire    string  `json:"expire"`
+	ExpiresAt *string `json:"expires_at"`
+	GZip      bool    `json:"gzip"`
+}
+
+// pasteSummaryJSON encodes the summary of a created paste. The key is
+// removed from the URL so the summary can be shared.
+func pasteSummaryJSON(data PasteResourceModel, pasteURL url.URL) (string, error) {
+	pasteURL.Fragment = ""
+
+	summary := pasteSummary{
+		ID:        data.ID.ValueString(),
+		URL:       pasteURL.String(),
+		Formatter: data.Formatter.ValueString(),
+		Expire:    data.Expire.ValueString(),
+		ExpiresAt: data.ExpiresAt.ValueStringPointer(),
+		GZip:      data.GZip.ValueBool(),
+	}
+
+	encoded, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// newIdempotencyKey returns a random key for a create request.
+func newIdempotencyKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// contentSHA256 returns the hex encoded SHA-256 of content.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkTextContent returns an error when content is not valid UTF-8 or
+// contains control characters other than tabs and line breaks.
+func checkTextContent(content []byte) error {
+	if !utf8.Valid(content) {
+		return errors.New("content is not valid UTF-8")
+	}
+
+	for i, r := range string(content) {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return fmt.Errorf("content contains control character %U at byte %d", r, i)
+		}
+	}
+
+	return nil
+}
+
+// appendLineMu serializes appendLine, since resources are created
+// concurrently within an apply.
+var appendLineMu sync.Mutex
+
+// appendLine appends line and a newline to the file at name, creating it
+// if needed.
+func appendLine(name, line string) error {
+	appendLineMu.Lock()
+	defer appendLineMu.Unlock()
+
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	// A single write keeps the line whole even if another process appends
+	// to the file
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
 }
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// renderContentTemplate renders the Go text/template in the file name with
+// vars. Referencing a variable missing from vars is an error rather than
+// rendering "<no value>".
+func renderContentTemplate(name string, vars map[string]string) ([]byte, error) {
+	text, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(filepath.Base(name)).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, vars); err != nil {
+		return nil, err
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// templateVars returns the template_vars of the resource as a plain map.
+// Null vars render as an empty map.
+func templateVars(vars types.Map) (map[string]string, error) {
+	result := make(map[string]string, len(vars.Elements()))
+	for name, value := range vars.Elements() {
+		s, ok := value.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, fmt.Errorf("template variable %q is not a known string", name)
+		}
+		result[name] = s.ValueString()
+	}
+
+	return result, nil
+}
+
+// pasteContentKnown reports whether the content of the paste can be
+// computed, which is not the case while any of its sources is unknown.
+func pasteContentKnown(data PasteResourceModel) bool {
+	if data.Content.IsUnknown() || data.ContentBase64.IsUnknown() || data.ContentTemplate.IsUnknown() || data.TemplateVars.IsUnknown() || data.ContentDir.IsUnknown() || data.ContentURL.IsUnknown() {
+		return false
+	}
+
+	for _, value := range data.TemplateVars.Elements() {
+		if value.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
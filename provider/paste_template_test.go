@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplate writes text to a template file in a temporary directory
+// and returns its path.
+func writeTemplate(t *testing.T, text string) string {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "paste.tmpl")
+	require.NoError(t, os.WriteFile(name, []byte(text), 0o600))
+
+	return name
+}
+
+func TestRenderContentTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		vars        map[string]string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "plain text",
+			text:     "no variables",
+			expected: "no variables",
+		},
+		{
+			name:     "variables",
+			text:     "env={{ .environment }} version={{ .version }}",
+			vars:     map[string]string{"environment": "prod", "version": "1.2.3"},
+			expected: "env=prod version=1.2.3",
+		},
+		{
+			name:        "undefined variable",
+			text:        "env={{ .environment }}",
+			vars:        map[string]string{"version": "1.2.3"},
+			expectError: true,
+		},
+		{
+			name:        "invalid template",
+			text:        "env={{ .environment",
+			vars:        map[string]string{"environment": "prod"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderContentTemplate(writeTemplate(t, tt.text), tt.vars)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+
+	_, err := renderContentTemplate(filepath.Join(t.TempDir(), "missing.tmpl"), nil)
+	assert.Error(t, err)
+}
+
+func TestTemplateVars(t *testing.T) {
+	vars, err := templateVars(types.MapNull(types.StringType))
+	require.NoError(t, err)
+	assert.Empty(t, vars)
+
+	vars, err = templateVars(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"environment": types.StringValue("prod"),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"environment": "prod"}, vars)
+
+	_, err = templateVars(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"environment": types.StringUnknown(),
+	}))
+	assert.Error(t, err)
+}
+
+func TestPasteResource_ValidateConfig_ContentTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     tftypes.Value
+		vars         tftypes.Value
+		expectedPath string
+	}{
+		{
+			name:     "rendered",
+			template: tftypes.NewValue(tftypes.String, writeTemplate(t, "env={{ .environment }}")),
+			vars: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, "prod"),
+			}),
+		},
+		{
+			name:         "undefined variable",
+			template:     tftypes.NewValue(tftypes.String, writeTemplate(t, "env={{ .environment }}")),
+			vars:         tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			expectedPath: "content_template",
+		},
+		{
+			name:         "renders empty",
+			template:     tftypes.NewValue(tftypes.String, writeTemplate(t, "")),
+			vars:         tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			expectedPath: "content_template",
+		},
+		{
+			name:     "unknown variable",
+			template: tftypes.NewValue(tftypes.String, writeTemplate(t, "env={{ .environment }}")),
+			vars: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"environment": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PasteResource{}
+			req := resource.ValidateConfigRequest{
+				Config: testResourceConfig(t, r, map[string]tftypes.Value{
+					"content_template": tt.template,
+					"template_vars":    tt.vars,
+				}),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			r.ValidateConfig(context.Background(), req, resp)
+
+			if tt.expectedPath == "" {
+				assert.False(t, resp.Diagnostics.HasError())
+				return
+			}
+
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.Equal(t, path.Root(tt.expectedPath), diagWithPath.Path())
+		})
+	}
+}
+
+func TestPasteResource_ModifyPlan_ContentTemplateChanged(t *testing.T) {
+	ctx := context.Background()
+	r := &PasteResource{}
+	name := writeTemplate(t, "env={{ .environment }}")
+
+	tests := []struct {
+		name           string
+		environment    string
+		expectReplaced bool
+	}{
+		{
+			name:        "unchanged",
+			environment: "prod",
+		},
+		{
+			name:           "rendered content changed",
+			environment:    "staging",
+			expectReplaced: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"id":               tftypes.NewValue(tftypes.String, "abcd1234"),
+				"content_template": tftypes.NewValue(tftypes.String, name),
+				"template_vars": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"environment": tftypes.NewValue(tftypes.String, "prod"),
+				}),
+				"content_sha256": tftypes.NewValue(tftypes.String, contentSHA256([]byte("env=prod"))),
+			}
+			state := testResourceConfig(t, r, values)
+
+			// Only the template file changes, not the configuration
+			require.NoError(t, os.WriteFile(name, []byte("env="+tt.environment), 0o600))
+			t.Cleanup(func() {
+				require.NoError(t, os.WriteFile(name, []byte("env={{ .environment }}"), 0o600))
+			})
+
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+				Plan:  tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			require.False(t, resp.Diagnostics.HasError())
+			if !tt.expectReplaced {
+				assert.Empty(t, resp.RequiresReplace)
+				assert.Zero(t, resp.Diagnostics.WarningsCount())
+				return
+			}
+
+			assert.Equal(t, path.Paths{path.Root("content_template")}, resp.RequiresReplace)
+			assert.Equal(t, 1, resp.Diagnostics.WarningsCount())
+
+			var sha types.String
+			require.False(t, resp.Plan.GetAttribute(ctx, path.Root("content_sha256"), &sha).HasError())
+			assert.Equal(t, contentSHA256([]byte("env="+tt.environment)), sha.ValueString())
+		})
+	}
+}
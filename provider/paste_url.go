@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// parsePasteURL parses a full paste URL such as https://host/?id#key and
+// returns it along with the paste ID. Instances serving pastes under a path,
+// such as https://host/paste/id#key, are supported by falling back to the
+// last path segment.
+func parsePasteURL(rawURL string) (*url.URL, string, error) {
+	pasteURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if pasteURL.Scheme == "" || pasteURL.Host == "" {
+		return nil, "", fmt.Errorf("expected an absolute URL like https://host/?id#key, got: %q", rawURL)
+	}
+
+	pasteID := pasteURL.Query().Get("pasteid")
+	if pasteID == "" && !strings.Contains(pasteURL.RawQuery, "=") {
+		pasteID = pasteURL.RawQuery
+	}
+
+	if pasteID == "" && pasteURL.RawQuery == "" {
+		pasteID = path.Base(strings.TrimSuffix(pasteURL.Path, "/"))
+		if pasteID == "." || pasteID == "/" {
+			pasteID = ""
+		}
+	}
+
+	if pasteID == "" {
+		return nil, "", fmt.Errorf("no paste ID found in URL %q", rawURL)
+	}
+
+	return pasteURL, pasteID, nil
+}
+
+// statePasteURL parses the paste URL kept in state. The key in its fragment
+// is what decrypts the paste, so when the URL has lost it, e.g. after being
+// edited in state, it is restored from masterKey.
+func statePasteURL(rawURL, masterKey string) (*url.URL, error) {
+	pasteURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if pasteURL.Fragment == "" && masterKey != "" {
+		pasteURL.Fragment = masterKey
+		pasteURL.RawFragment = ""
+	}
+
+	return pasteURL, nil
+}
+
+// pasteDeleteURL returns the link that deletes the paste with the given ID
+// and delete token, on the instance serving pasteURL, or "" when there is
+// no delete token.
+func pasteDeleteURL(pasteURL url.URL, pasteID, deleteToken string) string {
+	if deleteToken == "" {
+		return ""
+	}
+
+	deleteURL := pasteURL
+	deleteURL.RawQuery = url.Values{"pasteid": {pasteID}, "deletetoken": {deleteToken}}.Encode()
+	deleteURL.Fragment = ""
+	deleteURL.RawFragment = ""
+
+	return deleteURL.String()
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/RO-29/pastebin-go-cli"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePasteURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		expectedID  string
+		expectedKey string
+		expectError bool
+	}{
+		{
+			name:        "URL with key",
+			url:         "https://example.com/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+			expectedID:  "abcd1234",
+			expectedKey: "EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+		},
+		{
+			name:       "URL without key",
+			url:        "https://example.com/?abcd1234",
+			expectedID: "abcd1234",
+		},
+		{
+			name:        "pasteid query parameter",
+			url:         "https://example.com/?pasteid=abcd1234#key",
+			expectedID:  "abcd1234",
+			expectedKey: "key",
+		},
+		{
+			name:        "IPv6 host",
+			url:         "https://[2001:db8::1]:8443/?abcd1234#key",
+			expectedID:  "abcd1234",
+			expectedKey: "key",
+		},
+		{
+			name:        "path based paste ID",
+			url:         "https://example.com/paste/abcd1234#key",
+			expectedID:  "abcd1234",
+			expectedKey: "key",
+		},
+		{
+			name:       "path based paste ID with trailing slash",
+			url:        "https://example.com/abcd1234/",
+			expectedID: "abcd1234",
+		},
+		{
+			name:        "query without paste ID",
+			url:         "https://example.com/paste?lang=en",
+			expectError: true,
+		},
+		{
+			name:        "bare paste ID",
+			url:         "abcd1234",
+			expectError: true,
+		},
+		{
+			name:        "URL without paste ID",
+			url:         "https://example.com/#key",
+			expectError: true,
+		},
+		{
+			name:        "malformed URL",
+			url:         "ht tp://invalid url",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pasteURL, pasteID, err := parsePasteURL(tt.url)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, pasteID)
+			assert.Equal(t, tt.expectedKey, pasteURL.Fragment)
+		})
+	}
+}
+
+func TestStatePasteURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		masterKey string
+		expected  string
+	}{
+		{
+			name:      "key in fragment",
+			url:       "https://example.com/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+			masterKey: "EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+			expected:  "https://example.com/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF",
+		},
+		{
+			name:     "key in fragment without master key",
+			url:      "https://example.com/?abcd1234#key",
+			expected: "https://example.com/?abcd1234#key",
+		},
+		{
+			name:      "fragment restored from master key",
+			url:       "https://example.com/?abcd1234",
+			masterKey: "key",
+			expected:  "https://example.com/?abcd1234#key",
+		},
+		{
+			name:     "no key",
+			url:      "https://example.com/?abcd1234",
+			expected: "https://example.com/?abcd1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pasteURL, err := statePasteURL(tt.url, tt.masterKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pasteURL.String())
+		})
+	}
+
+	_, err := statePasteURL("https://example.com/%zz", "")
+	assert.Error(t, err)
+}
+
+func TestStatePasteURL_ShowPasteReceivesKey(t *testing.T) {
+	p := testFailoverProviderData(t)
+
+	pasteURL, err := statePasteURL("https://primary.example.com?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF", "")
+	require.NoError(t, err)
+
+	call := func(client *pastebin.Client, ctx context.Context, pasteURL url.URL, options pastebin.ShowPasteOptions) (url.URL, error) {
+		return pasteURL, nil
+	}
+
+	received, err := callWithFailover(context.Background(), p.hostClients(nil), opShowPaste, call, *pasteURL, pastebin.ShowPasteOptions{}, p.movePasteURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, "EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF", received.Fragment)
+	assert.Equal(t, "abcd1234", received.RawQuery)
+}
+
+func TestPasteDeleteURL(t *testing.T) {
+	pasteURL, err := url.Parse("https://example.com/paste/?abcd1234#key")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/paste/?deletetoken=token%2B1&pasteid=abcd1234", pasteDeleteURL(*pasteURL, "abcd1234", "token+1"))
+	assert.Empty(t, pasteDeleteURL(*pasteURL, "abcd1234", ""))
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/RO-29/pastebin-go-cli"
+)
+
+// maxConcurrentReads bounds how many pastes pastebin_pastes reads at once.
+const maxConcurrentReads = 4
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PastesDataSource{}
+
+func NewPastesDataSource() datasource.DataSource {
+	return &PastesDataSource{}
+}
+
+// PastesDataSource defines the data source implementation.
+type PastesDataSource struct {
+	providerData *ProviderData
+}
+
+// PastesDataSourceModel describes the data source data model.
+type PastesDataSourceModel struct {
+	Pastes  types.List `tfsdk:"pastes"`
+	Results types.List `tfsdk:"results"`
+}
+
+// PastesEntryModel describes an entry of pastes.
+type PastesEntryModel struct {
+	URL      types.String `tfsdk:"url"`
+	Password types.String `tfsdk:"password"`
+}
+
+// PastesResultModel describes an entry of results.
+type PastesResultModel struct {
+	URL            types.String `tfsdk:"url"`
+	ID             types.String `tfsdk:"id"`
+	Content        types.String `tfsdk:"content"`
+	AttachmentName types.String `tfsdk:"attachment_name"`
+	MimeType       types.String `tfsdk:"mime_type"`
+	SizeBytes      types.Int64  `tfsdk:"size_bytes"`
+	CommentCount   types.Int64  `tfsdk:"comment_count"`
+	Error          types.String `tfsdk:"error"`
+}
+
+// pastesResultAttrTypes are the attribute types of PastesResultModel.
+var pastesResultAttrTypes = map[string]attr.Type{
+	"url":             types.StringType,
+	"id":              types.StringType,
+	"content":         types.StringType,
+	"attachment_name": types.StringType,
+	"mime_type":       types.StringType,
+	"size_bytes":      types.Int64Type,
+	"comment_count":   types.Int64Type,
+	"error":           types.StringType,
+}
+
+func (d *PastesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pastes"
+}
+
+func (d *PastesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads several pastes at once. A paste that cannot be read is reported in its result " +
+			"and as a warning; the read only fails when no paste can be read. Burn-after-reading pastes are never burned.",
+
+		Attributes: map[string]schema.Attribute{
+			"pastes": schema.ListNestedAttribute{
+				MarkdownDescription: "Pastes to read",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							MarkdownDescription: "Full URL of the paste including master key",
+							Required:            true,
+						},
+						"password": schema.StringAttribute{
+							MarkdownDescription: "Password to decrypt the paste (if password protected)",
+							Optional:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "One result per entry of `pastes`, in the same order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							MarkdownDescription: "URL of the paste, as given in `pastes`",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Paste identifier",
+							Computed:            true,
+						},
+						"content": schema.StringAttribute{
+							MarkdownDescription: "The content of the paste",
+							Computed:            true,
+						},
+						"attachment_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the attachment (if paste is an attachment)",
+							Computed:            true,
+						},
+						"mime_type": schema.StringAttribute{
+							MarkdownDescription: "MIME type of attachment (if paste is an attachment)",
+							Computed:            true,
+						},
+						"size_bytes": schema.Int64Attribute{
+							MarkdownDescription: "Size of the attachment in bytes (if paste is an attachment)",
+							Computed:            true,
+						},
+						"comment_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of comments on the paste",
+							Computed:            true,
+						},
+						"error": schema.StringAttribute{
+							MarkdownDescription: "Why the paste could not be read, null on success",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PastesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *PastesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PastesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entries []PastesEntryModel
+	resp.Diagnostics.Append(data.Pastes.ElementsAs(ctx, &entries, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := readPastes(ctx, d.providerData, entries)
+
+	failed := 0
+	for i, result := range results {
+		if result.Error.IsNull() {
+			continue
+		}
+
+		failed++
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("pastes").AtListIndex(i),
+			"Paste Unavailable",
+			fmt.Sprintf("Paste %d could not be read: %s", i, result.Error.ValueString()),
+		)
+	}
+
+	if len(results) > 0 && failed == len(results) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pastes"),
+			"No Paste Could Be Read",
+			"None of the pastes could be read. They may not exist, may have expired or been burned, "+
+				"the passwords may be wrong, or the pastebin instance may be unreachable.",
+		)
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Results, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: pastesResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readPastes reads the pastes concurrently, at most maxConcurrentReads at
+// a time, and returns one result per entry in the same order.
+func readPastes(ctx context.Context, providerData *ProviderData, entries []PastesEntryModel) []PastesResultModel {
+	results := make([]PastesResultModel, len(entries))
+	sem := make(chan struct{}, maxConcurrentReads)
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = readPaste(ctx, providerData, entry)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// readPaste reads a single entry of pastebin_pastes. Errors are redacted,
+// as they end up in the state.
+func readPaste(ctx context.Context, providerData *ProviderData, entry PastesEntryModel) PastesResultModel {
+	result := PastesResultModel{
+		URL:            entry.URL,
+		ID:             types.StringNull(),
+		Content:        types.StringNull(),
+		AttachmentName: types.StringNull(),
+		MimeType:       types.StringNull(),
+		SizeBytes:      types.Int64Null(),
+		CommentCount:   types.Int64Null(),
+		Error:          types.StringNull(),
+	}
+
+	pasteURL, err := url.Parse(entry.URL.ValueString())
+	if err != nil {
+		result.Error = types.StringValue(fmt.Sprintf("unable to parse paste URL: %s", redactError(err)))
+		return result
+	}
+
+	options := pastebin.ShowPasteOptions{
+		Password:    []byte(entry.Password.ValueString()),
+		ConfirmBurn: false,
+	}
+
+	paste, err := callWithFailover(ctx, providerData.hostClients(nil), opShowPaste, (*pastebin.Client).ShowPaste, *pasteURL, options, providerData.movePasteURL)
+	if err != nil {
+		result.Error = types.StringValue(providerData.redactError(err, entry.Password.ValueString()))
+		return result
+	}
+
+	result.ID = types.StringValue(paste.PasteID)
+	result.Content = types.StringValue(string(paste.Paste.Data))
+	result.CommentCount = types.Int64Value(int64(paste.CommentCount))
+
+	if paste.Paste.AttachmentName != "" {
+		result.AttachmentName = types.StringValue(paste.Paste.AttachmentName)
+		result.MimeType = types.StringValue(paste.Paste.MimeType)
+		result.SizeBytes = types.Int64Value(int64(len(paste.Paste.Attachement)))
+	}
+
+	return result
+}
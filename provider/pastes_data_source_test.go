@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPastesDataSource_Metadata(t *testing.T) {
+	d := &PastesDataSource{}
+	ctx := context.Background()
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "pastebin",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	d.Metadata(ctx, req, resp)
+
+	assert.Equal(t, "pastebin_pastes", resp.TypeName)
+}
+
+func TestPastesDataSource_Schema(t *testing.T) {
+	d := &PastesDataSource{}
+	ctx := context.Background()
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	d.Schema(ctx, req, resp)
+
+	require.NotNil(t, resp.Schema.Attributes)
+
+	pastes, ok := resp.Schema.Attributes["pastes"].(schema.ListNestedAttribute)
+	require.True(t, ok)
+	assert.True(t, pastes.IsRequired())
+	assert.True(t, pastes.NestedObject.Attributes["url"].IsRequired())
+	assert.True(t, pastes.NestedObject.Attributes["password"].IsSensitive())
+
+	results, ok := resp.Schema.Attributes["results"].(schema.ListNestedAttribute)
+	require.True(t, ok)
+	assert.True(t, results.IsComputed())
+
+	// Every result attribute must have a matching attribute type.
+	assert.Len(t, results.NestedObject.Attributes, len(pastesResultAttrTypes))
+	for attrName := range pastesResultAttrTypes {
+		attr, exists := results.NestedObject.Attributes[attrName]
+		require.True(t, exists, "Expected attribute %s to be present in schema", attrName)
+		assert.True(t, attr.IsComputed(), "Attribute %s should be computed", attrName)
+	}
+}
+
+func TestPastesDataSource_Configure_InvalidProviderData(t *testing.T) {
+	d := &PastesDataSource{}
+	ctx := context.Background()
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "invalid",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	d.Configure(ctx, req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unexpected Data Source Configure Type")
+}
+
+func TestReadPastes_InvalidURLs(t *testing.T) {
+	entries := []PastesEntryModel{
+		{URL: types.StringValue("://bad")},
+		{URL: types.StringValue("://worse")},
+	}
+
+	results := readPastes(context.Background(), &ProviderData{}, entries)
+
+	require.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, entries[i].URL, result.URL)
+		assert.Contains(t, result.Error.ValueString(), "unable to parse paste URL")
+		assert.True(t, result.ID.IsNull())
+	}
+}
+
+func TestNewPastesDataSource(t *testing.T) {
+	d := NewPastesDataSource()
+	assert.NotNil(t, d)
+	assert.IsType(t, &PastesDataSource{}, d)
+}
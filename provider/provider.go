@@ -3,13 +3,30 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
 	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/RO-29/pastebin-go-cli"
@@ -17,6 +34,16 @@ import (
 
 // Ensure PastebinProvider satisfies various provider interfaces.
 var _ provider.Provider = &PastebinProvider{}
+var _ provider.ProviderWithEphemeralResources = &PastebinProvider{}
+var _ provider.ProviderWithFunctions = &PastebinProvider{}
+
+// localePattern matches the basic shape of a BCP 47 language tag: a primary
+// language subtag followed by optional script, region or variant subtags.
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// noControlCharactersPattern matches strings that are safe to use in a
+// header value.
+var noControlCharactersPattern = regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`)
 
 // PastebinProvider defines the provider implementation.
 type PastebinProvider struct {
@@ -24,21 +51,56 @@ type PastebinProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// onRequest is passed on to ProviderData.OnRequest.
+	onRequest RequestHook
 }
 
 // PastebinProviderModel describes the provider data model.
 type PastebinProviderModel struct {
-	Host             types.String `tfsdk:"host"`
-	Username         types.String `tfsdk:"username"`
-	Password         types.String `tfsdk:"password"`
-	SkipTLSVerify    types.Bool   `tfsdk:"skip_tls_verify"`
-	UserAgent        types.String `tfsdk:"user_agent"`
-	ExtraHeaders     types.Map    `tfsdk:"extra_headers"`
-	Expire           types.String `tfsdk:"expire"`
-	Formatter        types.String `tfsdk:"formatter"`
-	GZip             types.Bool   `tfsdk:"gzip"`
-	OpenDiscussion   types.Bool   `tfsdk:"open_discussion"`
-	BurnAfterReading types.Bool   `tfsdk:"burn_after_reading"`
+	Host              types.String         `tfsdk:"host"`
+	Hosts             types.List           `tfsdk:"hosts"`
+	Username          types.String         `tfsdk:"username"`
+	Password          types.String         `tfsdk:"password"`
+	UseNetrc          types.Bool           `tfsdk:"use_netrc"`
+	SkipTLSVerify     types.Bool           `tfsdk:"skip_tls_verify"`
+	AllowInsecureHTTP types.Bool           `tfsdk:"allow_insecure_http"`
+	AllowNeverExpire  types.Bool           `tfsdk:"allow_never_expire"`
+	DebugHTTP         types.Bool           `tfsdk:"debug_http"`
+	CACertPEM         types.String         `tfsdk:"ca_cert_pem"`
+	ClientCertPEM     types.String         `tfsdk:"client_cert_pem"`
+	ClientKeyPEM      types.String         `tfsdk:"client_key_pem"`
+	MinTLSVersion     types.String         `tfsdk:"min_tls_version"`
+	UserAgent         types.String         `tfsdk:"user_agent"`
+	UserAgentSuffix   types.String         `tfsdk:"user_agent_suffix"`
+	Locale            types.String         `tfsdk:"locale"`
+	ExtraHeaders      types.Map            `tfsdk:"extra_headers"`
+	ExtraHeadersList  types.List           `tfsdk:"extra_headers_list"`
+	CircuitBreaker    *CircuitBreakerModel `tfsdk:"circuit_breaker"`
+	Expire            types.String         `tfsdk:"expire"`
+	ExpireByFormatter types.Map            `tfsdk:"expire_by_formatter"`
+	Formatter         types.String         `tfsdk:"formatter"`
+	GZip              types.Bool           `tfsdk:"gzip"`
+	GZipFallback      types.Bool           `tfsdk:"gzip_fallback"`
+	OpenDiscussion    types.Bool           `tfsdk:"open_discussion"`
+	BurnAfterReading  types.Bool           `tfsdk:"burn_after_reading"`
+	MaxPasteSize      types.Int64          `tfsdk:"max_paste_size"`
+	MaxConcurrent     types.Int64          `tfsdk:"max_concurrent_requests"`
+	RequiredVersion   types.String         `tfsdk:"required_server_version"`
+	DefaultPassword   types.String         `tfsdk:"default_password"`
+}
+
+// HeaderModel describes an entry of extra_headers_list.
+type HeaderModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// CircuitBreakerModel describes the circuit_breaker provider setting.
+type CircuitBreakerModel struct {
+	Threshold types.Int64  `tfsdk:"threshold"`
+	Window    types.String `tfsdk:"window"`
+	Cooldown  types.String `tfsdk:"cooldown"`
 }
 
 func (p *PastebinProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -50,9 +112,18 @@ func (p *PastebinProvider) Schema(ctx context.Context, req provider.SchemaReques
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Pastebin instance host URL",
+				MarkdownDescription: "Pastebin instance host URL. IPv6 addresses must be enclosed in brackets, as in `https://[2001:db8::1]:8443`",
 				Optional:            true,
 			},
+			"hosts": schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Mirror host URLs holding the same pastes, tried in order after `host` when a host cannot be reached. " +
+					"When `host` is not set, the first entry is the primary host",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
 			"username": schema.StringAttribute{
 				MarkdownDescription: "Username for basic authentication",
 				Optional:            true,
@@ -62,38 +133,182 @@ func (p *PastebinProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"use_netrc": schema.BoolAttribute{
+				MarkdownDescription: "Read basic authentication credentials for the host from the netrc file (`$NETRC` or `~/.netrc`). " +
+					"Falls back to `username` and `password` when the file has no matching entry",
+				Optional: true,
+			},
 			"skip_tls_verify": schema.BoolAttribute{
-				MarkdownDescription: "Skip TLS certificate verification",
+				MarkdownDescription: "Skip TLS certificate verification. Reported as a warning on every plan and apply; prefer `ca_cert_pem`",
 				Optional:            true,
 			},
+			"allow_insecure_http": schema.BoolAttribute{
+				MarkdownDescription: "Allow a plain `http://` host, which sends paste contents, master keys and credentials unencrypted. " +
+					"Meant for local testing only",
+				Optional: true,
+			},
+			"allow_never_expire": schema.BoolAttribute{
+				MarkdownDescription: "Allow pastes that never expire. Set to false to reject `expire = \"never\"` at plan time, " +
+					"both as the provider default and on resources, to enforce a TTL on every paste. Defaults to true",
+				Optional: true,
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log the HTTP requests and responses the provider sends itself, such as health checks and redirect resolution, " +
+					"at the DEBUG level. Bodies are truncated and secrets redacted. Requests of the pastebin client are not logged. Defaults to false",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust instead of the system roots, for instances using a private PKI",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key_pem`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate. Requires `client_cert_pem`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"min_tls_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version accepted from the instance, `1.2` or `1.3`. Defaults to the Go default, currently TLS 1.2",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(slices.Sorted(maps.Keys(tlsVersions))...),
+				},
+			},
 			"user_agent": schema.StringAttribute{
 				MarkdownDescription: "Custom User-Agent header",
 				Optional:            true,
 			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent header, separated by a space, keeping the provider identification",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(noControlCharactersPattern, "must not contain control characters"),
+				},
+			},
+			"locale": schema.StringAttribute{
+				MarkdownDescription: "Preferred language for messages from the instance, sent as the `Accept-Language` header. " +
+					"Ignored when `extra_headers` or `extra_headers_list` already set `Accept-Language`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(localePattern, "must be a BCP 47 language tag such as en or pt-BR"),
+				},
+			},
 			"extra_headers": schema.MapAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "Extra HTTP headers to include in requests",
+				MarkdownDescription: "Extra HTTP headers to include in requests. `Accept-Encoding` cannot be set, as gzip is negotiated automatically",
 				Optional:            true,
 			},
+			"extra_headers_list": schema.ListNestedAttribute{
+				MarkdownDescription: "Extra HTTP headers to include in requests, applied in order after `extra_headers`. " +
+					"Unlike `extra_headers`, the same header name may appear more than once. `Accept-Encoding` cannot be set, as gzip is negotiated automatically",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Header name",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Header value",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"circuit_breaker": schema.SingleNestedAttribute{
+				MarkdownDescription: fmt.Sprintf("Stop calling a host that failed `threshold` times in a row within `window` until `cooldown` has passed, "+
+					"so a large apply against an unreachable instance fails fast instead of each paste waiting for its own timeout. "+
+					"Each host of `hosts` has its own breaker. Defaults to a threshold of %d, a window of %s and a cooldown of %s",
+					defaultBreakerThreshold, defaultBreakerWindow, defaultBreakerCooldown),
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"threshold": schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("Consecutive failures that open the breaker, 0 to disable it. Defaults to %d", defaultBreakerThreshold),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"window": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Duration, such as `1m`, within which the failures must occur. Defaults to %s", defaultBreakerWindow),
+						Optional:            true,
+					},
+					"cooldown": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Duration, such as `30s`, the breaker stays open before the host is tried again. Defaults to %s", defaultBreakerCooldown),
+						Optional:            true,
+					},
+				},
+			},
 			"expire": schema.StringAttribute{
 				MarkdownDescription: "Default expiration time for pastes",
 				Optional:            true,
 			},
+			"expire_by_formatter": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Default expiration time for pastes by formatter, e.g. `{ syntaxhighlighting = \"1month\" }`. " +
+					"Formatters without an entry use `expire`",
+				Optional: true,
+			},
 			"formatter": schema.StringAttribute{
 				MarkdownDescription: "Default formatter for pastes (plaintext, markdown, syntaxhighlighting)",
 				Optional:            true,
 			},
 			"gzip": schema.BoolAttribute{
-				MarkdownDescription: "Enable gzip compression by default",
+				MarkdownDescription: "Enable gzip compression by default. Pastes that set `gzip` themselves, including to false, keep their value. Defaults to true",
 				Optional:            true,
 			},
+			"gzip_fallback": schema.BoolAttribute{
+				MarkdownDescription: "Create a paste once more without compression when the instance rejects it compressed " +
+					"with `Invalid data.`, and log a warning. Other errors are never retried, since the paste may have been created. The paste `compressed` attribute records whether it was compressed. Defaults to false",
+				Optional: true,
+			},
 			"open_discussion": schema.BoolAttribute{
-				MarkdownDescription: "Enable discussion on pastes by default",
+				MarkdownDescription: "Enable discussion on pastes by default. Pastes that set `open_discussion` themselves, including to false, keep their value",
 				Optional:            true,
 			},
 			"burn_after_reading": schema.BoolAttribute{
-				MarkdownDescription: "Enable burn after reading by default",
+				MarkdownDescription: "Enable burn after reading by default. Pastes that set `burn_after_reading` themselves, including to false, keep their value",
+				Optional:            true,
+			},
+			"default_password": schema.StringAttribute{
+				MarkdownDescription: "Default password for pastes that set neither `password` nor `password_wo`. " +
+					"It is not written to each paste's state, and pastes created with it are read back with the current value",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_paste_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum paste size in bytes, checked before uploading. Unlimited when unset",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of paste creates and reads in flight at once, across all resources and data sources, " +
+					"for rate-limited instances. Further requests wait for one to finish. Unlimited when unset, " +
+					"so only Terraform's `-parallelism` bounds them",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"required_server_version": schema.StringAttribute{
+				MarkdownDescription: "PrivateBin versions the instance must run, as comma separated comparisons such as `>= 1.7, < 2.0` " +
+					"or `~> 1.7`. The version is read from the front page of `host` when the provider is configured, and a mismatch " +
+					"is an error. When the instance cannot be reached or does not report its version, the check is skipped with a warning",
+				Optional: true,
 			},
 		},
 	}
@@ -108,7 +323,16 @@ func (p *PastebinProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Configuration values are now available.
+	// Configuration values are now available. Environment variables fill
+	// in the attributes the configuration does not set.
+	data.Expire = stringFromEnv(data.Expire, "PASTEBIN_EXPIRE")
+	data.Formatter = stringFromEnv(data.Formatter, "PASTEBIN_FORMATTER")
+	data.UserAgent = stringFromEnv(data.UserAgent, "PASTEBIN_USER_AGENT")
+	data.GZip = boolFromEnv(data.GZip, "PASTEBIN_GZIP", path.Root("gzip"), &resp.Diagnostics)
+	data.OpenDiscussion = boolFromEnv(data.OpenDiscussion, "PASTEBIN_OPEN_DISCUSSION", path.Root("open_discussion"), &resp.Diagnostics)
+	data.BurnAfterReading = boolFromEnv(data.BurnAfterReading, "PASTEBIN_BURN_AFTER_READING", path.Root("burn_after_reading"), &resp.Diagnostics)
+	data.SkipTLSVerify = boolFromEnv(data.SkipTLSVerify, "PASTEBIN_SKIP_TLS_VERIFY", path.Root("skip_tls_verify"), &resp.Diagnostics)
+
 	host := os.Getenv("PASTEBIN_HOST")
 	if !data.Host.IsNull() {
 		host = data.Host.ValueString()
@@ -128,22 +352,183 @@ func (p *PastebinProvider) Configure(ctx context.Context, req provider.Configure
 	if !data.UserAgent.IsNull() {
 		userAgent = data.UserAgent.ValueString()
 	}
+	if !data.UserAgentSuffix.IsNull() && data.UserAgentSuffix.ValueString() != "" {
+		userAgent += " " + data.UserAgentSuffix.ValueString()
+	}
 
-	if host == "" {
-		resp.Diagnostics.AddError(
+	// Collect every configuration problem before returning, so all of them
+	// can be fixed in a single plan cycle.
+	var hosts []string
+	if !data.Hosts.IsNull() {
+		resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &hosts, false)...)
+	}
+
+	var hostURL *url.URL
+	var mirrors []url.URL
+	if host == "" && len(hosts) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
 			"Unknown Pastebin Host",
 			"The provider cannot create the Pastebin API client as there is an unknown configuration value for the Pastebin host. "+
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the PASTEBIN_HOST environment variable.",
 		)
-		return
+	} else if host != "" {
+		hostURL = checkHostURL(path.Root("host"), host, data.AllowInsecureHTTP.ValueBool(), &resp.Diagnostics)
 	}
 
-	hostURL, err := url.Parse(host)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid Pastebin Host",
-			"The provided host URL is invalid: "+err.Error(),
+	for i, h := range hosts {
+		mirrorURL := checkHostURL(path.Root("hosts").AtListIndex(i), h, data.AllowInsecureHTTP.ValueBool(), &resp.Diagnostics)
+		switch {
+		case mirrorURL == nil:
+		case host == "" && hostURL == nil:
+			hostURL = mirrorURL
+		default:
+			mirrors = append(mirrors, *mirrorURL)
+		}
+	}
+
+	if !data.Expire.IsNull() && !slices.Contains(knownExpirations, data.Expire.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expire"),
+			"Invalid Default Expiration",
+			fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownExpirations, ", "), data.Expire.ValueString()),
+		)
+	}
+
+	forbidNeverExpire := !data.AllowNeverExpire.IsNull() && !data.AllowNeverExpire.ValueBool()
+	if forbidNeverExpire && data.Expire.ValueString() == neverExpire {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expire"),
+			"Never Expiring Pastes Not Allowed",
+			"The default expire is never, but allow_never_expire = false forbids pastes that never expire. "+
+				"Set expire to a finite duration such as 1month.",
+		)
+	}
+
+	breaker := parseCircuitBreaker(data.CircuitBreaker, &resp.Diagnostics)
+
+	var versionConstraints []versionConstraint
+	if !data.RequiredVersion.IsNull() {
+		var err error
+		versionConstraints, err = parseVersionConstraints(data.RequiredVersion.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("required_server_version"),
+				"Invalid Version Constraint",
+				"The required server version is invalid: "+err.Error(),
+			)
+		}
+	}
+
+	expireByFormatter := make(map[string]string)
+	resp.Diagnostics.Append(data.ExpireByFormatter.ElementsAs(ctx, &expireByFormatter, false)...)
+
+	for _, formatter := range slices.Sorted(maps.Keys(expireByFormatter)) {
+		attrPath := path.Root("expire_by_formatter").AtMapKey(formatter)
+		expire := expireByFormatter[formatter]
+
+		switch {
+		case !slices.Contains(knownFormatters, formatter):
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Invalid Formatter",
+				fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownFormatters, ", "), formatter),
+			)
+		case !slices.Contains(knownExpirations, expire):
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Invalid Default Expiration",
+				fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownExpirations, ", "), expire),
+			)
+		case forbidNeverExpire && expire == neverExpire:
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Never Expiring Pastes Not Allowed",
+				fmt.Sprintf("The default expire of %s pastes is never, but allow_never_expire = false forbids pastes that never expire. "+
+					"Set it to a finite duration such as 1month.", formatter),
+			)
+		}
+	}
+
+	if !data.Formatter.IsNull() && !slices.Contains(knownFormatters, data.Formatter.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("formatter"),
+			"Invalid Default Formatter",
+			fmt.Sprintf("Expected one of %s, got: %q", strings.Join(knownFormatters, ", "), data.Formatter.ValueString()),
+		)
+	}
+
+	if data.UseNetrc.ValueBool() && hostURL != nil {
+		name, err := netrcPath()
+		if err == nil {
+			var netrcUsername, netrcPassword string
+			var found bool
+			netrcUsername, netrcPassword, found, err = netrcCredentials(name, hostURL.Hostname())
+			if found {
+				username, password = netrcUsername, netrcPassword
+			}
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("use_netrc"),
+				"Unable to Read Netrc",
+				"The netrc file could not be read: "+err.Error(),
+			)
+		}
+	}
+
+	headers := make(map[string]string)
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
+	}
+
+	var headersList []HeaderModel
+	if !data.ExtraHeadersList.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeadersList.ElementsAs(ctx, &headersList, false)...)
+	}
+
+	// Headers from the environment fill in those the configuration does
+	// not set
+	if envHeaders := os.Getenv("PASTEBIN_EXTRA_HEADERS"); envHeaders != "" {
+		fields, malformed := parseHeaderLines(envHeaders)
+
+		configHeaders := maps.Clone(headers)
+		for _, h := range fields {
+			if !hasHeader(configHeaders, headersList, h.Name) {
+				headers[h.Name] = h.Value
+			}
+		}
+
+		if len(malformed) > 0 {
+			lines := make([]string, len(malformed))
+			for i, line := range malformed {
+				lines[i] = strconv.Itoa(line)
+			}
+
+			resp.Diagnostics.AddWarning(
+				"Malformed Extra Headers",
+				fmt.Sprintf("These lines of PASTEBIN_EXTRA_HEADERS are not in the form \"Name: value\" and were skipped: %s.", strings.Join(lines, ", ")),
+			)
+		}
+	}
+
+	if hasHeader(headers, headersList, acceptEncodingHeader) {
+		resp.Diagnostics.AddError("Unsupported Extra Header", acceptEncodingDetail)
+	}
+
+	tlsConfig, diags := newTLSConfig(data)
+	resp.Diagnostics.Append(diags...)
+
+	if data.SkipTLSVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("skip_tls_verify"),
+			"TLS Verification Disabled",
+			"The provider does not verify the certificate of the pastebin instance, so paste contents and credentials "+
+				"can be intercepted. For instances signed by a private CA, trust that CA with ca_cert_pem instead.",
 		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -156,36 +541,53 @@ func (p *PastebinProvider) Configure(ctx context.Context, req provider.Configure
 		clientOptions = append(clientOptions, pastebin.WithBasicAuth(username, password))
 	}
 
-	if !data.SkipTLSVerify.IsNull() && data.SkipTLSVerify.ValueBool() {
-		tlsConfig := &tls.Config{
-			InsecureSkipVerify: true,
-		}
+	if tlsConfig != nil {
 		clientOptions = append(clientOptions, pastebin.WithTLSConfig(tlsConfig))
 	}
 
-	if !data.ExtraHeaders.IsNull() {
-		headers := make(map[string]string)
-		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	// Collect headers in the order they are applied: the locale, map
+	// headers in a stable order, then the ordered list
+	var headerFields []headerField
+	if !data.Locale.IsNull() && !hasHeader(headers, headersList, "Accept-Language") {
+		headerFields = append(headerFields, headerField{Name: "Accept-Language", Value: data.Locale.ValueString()})
+	}
 
-		for k, v := range headers {
-			clientOptions = append(clientOptions, pastebin.WithCustomHeaderField(k, v))
-		}
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		headerFields = append(headerFields, headerField{Name: k, Value: headers[k]})
 	}
 
-	// Create the client
-	client := pastebin.NewClient(*hostURL, clientOptions...)
+	for _, h := range headersList {
+		headerFields = append(headerFields, headerField{Name: h.Name.ValueString(), Value: h.Value.ValueString()})
+	}
 
 	// Create provider data struct
 	providerData := &ProviderData{
-		Client:           client,
-		Expire:           data.Expire.ValueString(),
-		Formatter:        data.Formatter.ValueString(),
-		GZip:             data.GZip.ValueBool(),
-		OpenDiscussion:   data.OpenDiscussion.ValueBool(),
-		BurnAfterReading: data.BurnAfterReading.ValueBool(),
+		Endpoint:          *hostURL,
+		Mirrors:           mirrors,
+		ClientOptions:     clientOptions,
+		Headers:           headerFields,
+		TLSConfig:         tlsConfig,
+		Username:          username,
+		Password:          password,
+		Expire:            data.Expire.ValueString(),
+		ExpireByFormatter: expireByFormatter,
+		Formatter:         data.Formatter.ValueString(),
+		GZip:              boolPointer(data.GZip),
+		GZipFallback:      data.GZipFallback.ValueBool(),
+		OpenDiscussion:    boolPointer(data.OpenDiscussion),
+		BurnAfterReading:  boolPointer(data.BurnAfterReading),
+		MaxPasteSize:      data.MaxPasteSize.ValueInt64(),
+		ForbidNeverExpire: forbidNeverExpire,
+		DebugHTTP:         data.DebugHTTP.ValueBool(),
+		DefaultPassword:   data.DefaultPassword.ValueString(),
+		Secrets:           []string{password, data.DefaultPassword.ValueString()},
+		OnRequest:         p.onRequest,
+	}
+
+	for _, h := range headerFields {
+		if isSensitiveHeader(h.Name) {
+			providerData.Secrets = append(providerData.Secrets, h.Value)
+		}
 	}
 
 	// Set defaults if not specified
@@ -196,8 +598,271 @@ func (p *PastebinProvider) Configure(ctx context.Context, req provider.Configure
 		providerData.Formatter = "plaintext"
 	}
 
+	// Create the clients
+	providerData.Client = providerData.newClient(nil)
+	for _, mirror := range mirrors {
+		providerData.mirrorClients = append(providerData.mirrorClients, providerData.newClientFor(mirror, nil))
+	}
+
+	if n := data.MaxConcurrent.ValueInt64(); n > 0 {
+		providerData.requestSlots = make(chan struct{}, n)
+	}
+
+	if breaker.threshold > 0 {
+		for _, host := range append([]url.URL{providerData.Endpoint}, mirrors...) {
+			providerData.breakers = append(providerData.breakers, newCircuitBreaker(host.Redacted(), breaker.threshold, breaker.window, breaker.cooldown))
+		}
+	}
+
+	if len(versionConstraints) > 0 {
+		providerData.checkServerVersion(ctx, data.RequiredVersion.ValueString(), versionConstraints, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// circuitBreakerSettings are the resolved circuit_breaker settings.
+type circuitBreakerSettings struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+}
+
+// parseCircuitBreaker resolves the circuit_breaker setting, filling in the
+// defaults and adding an error to diags for invalid durations.
+func parseCircuitBreaker(model *CircuitBreakerModel, diags *diag.Diagnostics) circuitBreakerSettings {
+	settings := circuitBreakerSettings{
+		threshold: defaultBreakerThreshold,
+		window:    defaultBreakerWindow,
+		cooldown:  defaultBreakerCooldown,
+	}
+
+	if model == nil {
+		return settings
+	}
+
+	if !model.Threshold.IsNull() {
+		settings.threshold = int(model.Threshold.ValueInt64())
+	}
+
+	durations := []struct {
+		name  string
+		value types.String
+		dest  *time.Duration
+	}{
+		{"window", model.Window, &settings.window},
+		{"cooldown", model.Cooldown, &settings.cooldown},
+	}
+
+	for _, d := range durations {
+		if d.value.IsNull() {
+			continue
+		}
+
+		duration, err := time.ParseDuration(d.value.ValueString())
+		if err == nil && duration <= 0 {
+			err = errors.New("duration must be positive")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("circuit_breaker").AtName(d.name),
+				"Invalid Circuit Breaker Duration",
+				fmt.Sprintf("Expected a duration such as 30s or 1m, got: %q: %s", d.value.ValueString(), err),
+			)
+			continue
+		}
+
+		*d.dest = duration
+	}
+
+	return settings
+}
+
+// checkHostURL parses a pastebin instance URL configured at p, adding an
+// error to diags and returning nil when it is invalid or, unless
+// allowInsecure is set, uses plain http.
+func checkHostURL(p path.Path, host string, allowInsecure bool, diags *diag.Diagnostics) *url.URL {
+	hostURL, err := parseHostURL(host)
+	if err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Pastebin Host",
+			"The provided host URL is invalid: "+err.Error(),
+		)
+		return nil
+	}
+
+	if hostURL.Scheme == "http" && !allowInsecure {
+		diags.AddAttributeError(
+			p,
+			"Insecure Pastebin Host",
+			"The host uses plain http, which would send paste contents, master keys and credentials unencrypted. "+
+				"Use an https host, or set allow_insecure_http = true for local testing.",
+		)
+		return nil
+	}
+
+	return hostURL
+}
+
+// parseHostURL parses the pastebin instance URL. It must be absolute, and
+// IPv6 addresses must be enclosed in brackets, as in https://[2001:db8::1]:8443.
+func parseHostURL(host string) (*url.URL, error) {
+	hostURL, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if hostURL.Scheme == "" || hostURL.Host == "" {
+		return nil, fmt.Errorf("expected an absolute URL such as https://pastebin.example.com or https://[2001:db8::1]:8443, got: %q", host)
+	}
+
+	// Depending on the Go version, url.Parse accepts an unbracketed IPv6
+	// address and treats its last group as the port
+	if !strings.HasPrefix(hostURL.Host, "[") && strings.Count(hostURL.Host, ":") > 1 {
+		return nil, fmt.Errorf("IPv6 addresses must be enclosed in brackets, as in https://[2001:db8::1]:8443, got: %q", host)
+	}
+
+	return hostURL, nil
+}
+
+// acceptEncodingHeader is the header the HTTP transport sets to negotiate
+// gzip with the instance. Go only decompresses responses transparently when
+// it set the header itself, so extra headers must not set it.
+const acceptEncodingHeader = "Accept-Encoding"
+
+// acceptEncodingDetail explains why extra headers cannot set
+// acceptEncodingHeader.
+const acceptEncodingDetail = "Requests already advertise Accept-Encoding: gzip, and gzip responses from compressing " +
+	"reverse proxies are decompressed transparently. Setting the header stops that, so paste responses could not be decoded. " +
+	"Remove Accept-Encoding from the extra headers."
+
+// hasHeader reports whether the extra headers set the named header. Header
+// names are compared case-insensitively.
+func hasHeader(headers map[string]string, headersList []HeaderModel, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+
+	for _, h := range headersList {
+		if strings.EqualFold(h.Name.ValueString(), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseHeaderLines parses headers given one per line as "Name: value",
+// skipping blank lines. Malformed lines are skipped too, and their 1-based
+// numbers returned.
+func parseHeaderLines(s string) ([]headerField, []int) {
+	var fields []headerField
+	var malformed []int
+
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !ok || !isHeaderName(name) {
+			malformed = append(malformed, i+1)
+			continue
+		}
+
+		fields = append(fields, headerField{Name: name, Value: strings.TrimSpace(value)})
+	}
+
+	return fields, malformed
+}
+
+// isHeaderName reports whether name is a valid HTTP header name.
+func isHeaderName(name string) bool {
+	return name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r)
+	}) == -1
+}
+
+// tlsVersions maps the accepted min_tls_version values to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig builds the client TLS configuration, or returns nil when the
+// defaults are used.
+func newTLSConfig(data PastebinProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tlsConfig *tls.Config
+
+	if data.SkipTLSVerify.ValueBool() {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	if !data.CACertPEM.IsNull() {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(data.CACertPEM.ValueString())) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid CA Certificate",
+				"The CA bundle must contain at least one PEM encoded certificate.",
+			)
+			return nil, diags
+		}
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if !data.MinTLSVersion.IsNull() {
+		version, ok := tlsVersions[data.MinTLSVersion.ValueString()]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("min_tls_version"),
+				"Invalid Minimum TLS Version",
+				fmt.Sprintf("Expected one of %s, got: %q", strings.Join(slices.Sorted(maps.Keys(tlsVersions)), ", "), data.MinTLSVersion.ValueString()),
+			)
+			return nil, diags
+		}
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if !data.ClientCertPEM.IsNull() && !data.ClientKeyPEM.IsNull() {
+		cert, err := tls.X509KeyPair([]byte(data.ClientCertPEM.ValueString()), []byte(data.ClientKeyPEM.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert_pem"),
+				"Invalid Client Certificate",
+				"The client certificate and key could not be loaded as a pair: "+err.Error(),
+			)
+			return nil, diags
+		}
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, diags
 }
 
 func (p *PastebinProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -209,23 +874,256 @@ func (p *PastebinProvider) Resources(ctx context.Context) []func() resource.Reso
 func (p *PastebinProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPasteDataSource,
+		NewCapabilitiesDataSource,
+		NewPasteExistsDataSource,
+		NewPastesDataSource,
+		NewHealthDataSource,
+	}
+}
+
+func (p *PastebinProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPasteEphemeralResource,
+	}
+}
+
+func (p *PastebinProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewPasteIDFromURLFunction,
+		NewPasteIsExpiredFunction,
+		NewValidatePasswordStrengthFunction,
+		NewOneTimeLinkFunction,
 	}
 }
 
 func New(version string) func() provider.Provider {
+	return NewWithRequestHook(version, nil)
+}
+
+// NewWithRequestHook is like New, and calls onRequest around each call the
+// provider makes to the pastebin client, e.g. to export metrics. A nil
+// onRequest disables the hook.
+func NewWithRequestHook(version string, onRequest RequestHook) func() provider.Provider {
 	return func() provider.Provider {
 		return &PastebinProvider{
-			version: version,
+			version:   version,
+			onRequest: onRequest,
 		}
 	}
 }
 
 // ProviderData contains the configured client and default settings
 type ProviderData struct {
-	Client           *pastebin.Client
-	Expire           string
-	Formatter        string
-	GZip             bool
-	OpenDiscussion   bool
-	BurnAfterReading bool
+	Client *pastebin.Client
+
+	// Endpoint, ClientOptions and Headers are the settings Client was built
+	// from, kept to build clients with per-resource headers. ClientOptions
+	// excludes the headers.
+	Endpoint      url.URL
+	ClientOptions []pastebin.Option
+	Headers       []headerField
+
+	// Mirrors are hosts holding the same pastes as Endpoint, tried in
+	// order when it cannot be reached, and mirrorClients their clients.
+	Mirrors       []url.URL
+	mirrorClients []*pastebin.Client
+
+	// TLSConfig is the TLS configuration of Client, nil for the default.
+	TLSConfig *tls.Config
+
+	// Username and Password are the basic authentication credentials of
+	// Client, for requests the provider sends itself. Empty when unset.
+	Username string
+	Password string
+
+	Expire          string
+	Formatter       string
+	DefaultPassword string
+
+	// ExpireByFormatter overrides Expire for pastes using the formatter.
+	ExpireByFormatter map[string]string
+
+	// GZip, OpenDiscussion and BurnAfterReading are nil when the provider
+	// leaves them unset, so resources fall back to their own defaults.
+	GZip             *bool
+	OpenDiscussion   *bool
+	BurnAfterReading *bool
+
+	// GZipFallback creates pastes uncompressed when the instance rejects
+	// them compressed.
+	GZipFallback bool
+
+	// MaxPasteSize is the maximum paste size in bytes, 0 means unlimited.
+	MaxPasteSize int64
+
+	// ForbidNeverExpire rejects pastes that never expire.
+	ForbidNeverExpire bool
+
+	// DebugHTTP logs the HTTP requests the provider sends itself.
+	DebugHTTP bool
+
+	// Secrets are configured values redacted from error messages.
+	Secrets []string
+
+	// OnRequest, when set, is called after each client call.
+	OnRequest RequestHook
+
+	// breakers are the circuit breakers of the hosts, the primary host
+	// first. Empty when circuit breaking is disabled.
+	breakers []*circuitBreaker
+
+	// requestSlots holds a value for each client call in flight, so its
+	// capacity is max_concurrent_requests. Nil when unlimited.
+	requestSlots chan struct{}
+}
+
+// boolPointer returns a pointer to the value of v, or nil when v is null or
+// unknown.
+func boolPointer(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	b := v.ValueBool()
+	return &b
+}
+
+// stringFromEnv returns v when it is set, otherwise the value of the
+// environment variable name when that is not empty.
+func stringFromEnv(v types.String, name string) types.String {
+	if !v.IsNull() {
+		return v
+	}
+
+	if env := os.Getenv(name); env != "" {
+		return types.StringValue(env)
+	}
+
+	return v
+}
+
+// boolFromEnv returns v when it is set, otherwise the value of the
+// environment variable name when that is not empty. A value that is not a
+// boolean adds an error at attrPath.
+func boolFromEnv(v types.Bool, name string, attrPath path.Path, diags *diag.Diagnostics) types.Bool {
+	if !v.IsNull() {
+		return v
+	}
+
+	env := os.Getenv(name)
+	if env == "" {
+		return v
+	}
+
+	b, err := strconv.ParseBool(env)
+	if err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Environment Variable",
+			fmt.Sprintf("Expected %s to be a boolean such as true or false, got: %q", name, env),
+		)
+		return v
+	}
+
+	return types.BoolValue(b)
+}
+
+// resolveBool returns v when it is set, otherwise the provider default when
+// set, otherwise fallback.
+func resolveBool(v types.Bool, providerDefault *bool, fallback bool) bool {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueBool()
+	}
+	if providerDefault != nil {
+		return *providerDefault
+	}
+	return fallback
+}
+
+// headerField is an HTTP header sent with every request.
+type headerField struct {
+	Name  string
+	Value string
+}
+
+// newClient builds a client for the provider settings with the given extra
+// headers. Provider headers with the same name, compared case-insensitively,
+// are replaced by the extra headers.
+func (p *ProviderData) newClient(headers map[string]string) *pastebin.Client {
+	return p.newClientFor(p.Endpoint, headers)
+}
+
+// newClientFor is newClient for the given host. The extra options are
+// applied last, so they override the provider settings.
+func (p *ProviderData) newClientFor(endpoint url.URL, headers map[string]string, extra ...pastebin.Option) *pastebin.Client {
+	options := slices.Clone(p.ClientOptions)
+
+	for _, h := range p.Headers {
+		if !hasHeader(headers, nil, h.Name) {
+			options = append(options, pastebin.WithCustomHeaderField(h.Name, h.Value))
+		}
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		options = append(options, pastebin.WithCustomHeaderField(k, headers[k]))
+	}
+
+	return pastebin.NewClient(endpoint, append(options, extra...)...)
+}
+
+// clientWithHeaders returns the client to use for a resource setting the
+// given extra headers and client options, which is Client when there are
+// none.
+func (p *ProviderData) clientWithHeaders(headers map[string]string, options ...pastebin.Option) *pastebin.Client {
+	if len(headers) == 0 && len(options) == 0 {
+		return p.Client
+	}
+
+	return p.newClientFor(p.Endpoint, headers, options...)
+}
+
+// redactError returns the message of err with the provider secrets and the
+// given ones redacted.
+func (p *ProviderData) redactError(err error, secrets ...string) string {
+	return redactError(err, append(secrets, p.Secrets...)...)
+}
+
+// pasteURL returns the URL of the paste with the given ID and key on the
+// provider host.
+func (p *ProviderData) pasteURL(pasteID, masterKey string) *url.URL {
+	pasteURL := p.Endpoint
+	pasteURL.RawQuery = pasteID
+	pasteURL.Fragment = masterKey
+
+	return &pasteURL
+}
+
+// defaultExpire returns the expiration time of pastes using formatter that
+// do not set their own.
+func (p *ProviderData) defaultExpire(formatter string) string {
+	if expire, ok := p.ExpireByFormatter[formatter]; ok {
+		return expire
+	}
+
+	return p.Expire
+}
+
+// checkExpire returns an error when pastes with the expire setting are not
+// allowed.
+func (p *ProviderData) checkExpire(expire string) error {
+	if p.ForbidNeverExpire && expire == neverExpire {
+		return errors.New("pastes that never expire are forbidden by the provider setting allow_never_expire = false")
+	}
+
+	return nil
+}
+
+// checkPasteSize returns an error when a paste of size bytes exceeds
+// MaxPasteSize.
+func (p *ProviderData) checkPasteSize(size int) error {
+	if p.MaxPasteSize > 0 && int64(size) > p.MaxPasteSize {
+		return fmt.Errorf("paste is %d bytes, which exceeds the provider max_paste_size of %d bytes", size, p.MaxPasteSize)
+	}
+
+	return nil
 }
@@ -2,13 +2,27 @@ package provider
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +53,11 @@ func TestPastebinProvider_Schema(t *testing.T) {
 	expectedAttributes := []string{
 		"host", "username", "password", "skip_tls_verify", "user_agent",
 		"extra_headers", "expire", "formatter", "gzip", "open_discussion", "burn_after_reading",
+		"max_paste_size", "extra_headers_list", "client_cert_pem", "client_key_pem",
+		"ca_cert_pem", "default_password", "use_netrc", "locale",
+		"user_agent_suffix", "allow_insecure_http", "hosts",
+		"allow_never_expire", "min_tls_version", "debug_http", "expire_by_formatter", "circuit_breaker",
+		"gzip_fallback", "max_concurrent_requests", "required_server_version",
 	}
 
 	for _, attr := range expectedAttributes {
@@ -50,6 +69,12 @@ func TestPastebinProvider_Schema(t *testing.T) {
 	passwordAttr := resp.Schema.Attributes["password"]
 	assert.True(t, passwordAttr.IsSensitive(), "Password attribute should be sensitive")
 
+	// Verify client key and default password are sensitive
+	for _, attrName := range []string{"client_key_pem", "default_password"} {
+		attr := resp.Schema.Attributes[attrName]
+		assert.True(t, attr.IsSensitive(), "Attribute %s should be sensitive", attrName)
+	}
+
 	// Verify all attributes are optional
 	for name, attr := range resp.Schema.Attributes {
 		assert.True(t, attr.IsOptional(), "Attribute %s should be optional", name)
@@ -158,6 +183,944 @@ func TestPastebinProvider_Configure_URLValidation(t *testing.T) {
 	}
 }
 
+func TestParseHostURL(t *testing.T) {
+	tests := []struct {
+		name             string
+		host             string
+		expectedHost     string
+		expectedHostname string
+		expectedPort     string
+		expectError      bool
+	}{
+		{
+			name:             "hostname",
+			host:             "https://example.com",
+			expectedHost:     "example.com",
+			expectedHostname: "example.com",
+		},
+		{
+			name:             "IPv6 literal with port",
+			host:             "https://[2001:db8::1]:8443",
+			expectedHost:     "[2001:db8::1]:8443",
+			expectedHostname: "2001:db8::1",
+			expectedPort:     "8443",
+		},
+		{
+			name:             "IPv6 literal without port",
+			host:             "https://[::1]/",
+			expectedHost:     "[::1]",
+			expectedHostname: "::1",
+		},
+		{
+			name:             "IPv6 literal with zone",
+			host:             "https://[fe80::1%25eth0]:8443",
+			expectedHost:     "[fe80::1%eth0]:8443",
+			expectedHostname: "fe80::1%eth0",
+			expectedPort:     "8443",
+		},
+		{
+			name:        "IPv6 literal without brackets",
+			host:        "https://2001:db8::1",
+			expectError: true,
+		},
+		{
+			name:        "IPv6 literal without scheme",
+			host:        "[2001:db8::1]:8443",
+			expectError: true,
+		},
+		{
+			name:        "hostname without scheme",
+			host:        "example.com",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostURL, err := parseHostURL(tt.host)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHost, hostURL.Host)
+			assert.Equal(t, tt.expectedHostname, hostURL.Hostname())
+			assert.Equal(t, tt.expectedPort, hostURL.Port())
+
+			// The client resolves paste IDs against the host URL, which
+			// must keep the brackets in the authority
+			pasteURL := hostURL.ResolveReference(&url.URL{RawQuery: "abcd1234"})
+			assert.Equal(t, tt.expectedHost, pasteURL.Host)
+		})
+	}
+}
+
+func TestPastebinProvider_Configure_IPv6Host(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "https://[2001:db8::1]:8443"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	assert.Equal(t, "https://[2001:db8::1]:8443", providerData.Endpoint.String())
+}
+
+func TestPastebinProvider_Configure_CollectsAllErrors(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":      tftypes.NewValue(tftypes.String, "ht tp://invalid url"),
+			"expire":    tftypes.NewValue(tftypes.String, "2weeks"),
+			"formatter": tftypes.NewValue(tftypes.String, "html"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	summaries := []string{}
+	for _, d := range resp.Diagnostics.Errors() {
+		summaries = append(summaries, d.Summary())
+	}
+	assert.ElementsMatch(t, []string{"Invalid Pastebin Host", "Invalid Default Expiration", "Invalid Default Formatter"}, summaries)
+	assert.Nil(t, resp.ResourceData)
+}
+
+func TestPastebinProvider_Configure_Success(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":   tftypes.NewValue(tftypes.String, "https://example.com"),
+			"expire": tftypes.NewValue(tftypes.String, "1day"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	assert.NotNil(t, providerData.Client)
+	assert.Equal(t, "1day", providerData.Expire)
+	assert.Equal(t, "plaintext", providerData.Formatter)
+	assert.Equal(t, "https://example.com", providerData.Endpoint.String())
+}
+
+func TestPastebinProvider_Configure_AllowNeverExpire(t *testing.T) {
+	tests := []struct {
+		name             string
+		expire           tftypes.Value
+		allowNeverExpire tftypes.Value
+		expectError      bool
+		expectForbidden  bool
+	}{
+		{
+			name:             "never allowed by default",
+			expire:           tftypes.NewValue(tftypes.String, "never"),
+			allowNeverExpire: tftypes.NewValue(tftypes.Bool, nil),
+		},
+		{
+			name:             "never default forbidden",
+			expire:           tftypes.NewValue(tftypes.String, "never"),
+			allowNeverExpire: tftypes.NewValue(tftypes.Bool, false),
+			expectError:      true,
+		},
+		{
+			name:             "finite default",
+			expire:           tftypes.NewValue(tftypes.String, "1month"),
+			allowNeverExpire: tftypes.NewValue(tftypes.Bool, false),
+			expectForbidden:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PastebinProvider{version: "test"}
+			ctx := context.Background()
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host":               tftypes.NewValue(tftypes.String, "https://example.com"),
+					"expire":             tt.expire,
+					"allow_never_expire": tt.allowNeverExpire,
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(ctx, req, resp)
+
+			if tt.expectError {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Never Expiring Pastes Not Allowed", resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError())
+			providerData, ok := resp.ResourceData.(*ProviderData)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectForbidden, providerData.ForbidNeverExpire)
+		})
+	}
+}
+
+func TestProviderData_CheckExpire(t *testing.T) {
+	p := &ProviderData{}
+	assert.NoError(t, p.checkExpire("never"))
+
+	p.ForbidNeverExpire = true
+	assert.Error(t, p.checkExpire("never"))
+	assert.NoError(t, p.checkExpire("1year"))
+}
+
+func TestPastebinProvider_Configure_ExpireByFormatter(t *testing.T) {
+	expireByFormatter := func(entries map[string]string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(entries))
+		for formatter, expire := range entries {
+			values[formatter] = tftypes.NewValue(tftypes.String, expire)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+	}
+
+	tests := []struct {
+		name              string
+		expireByFormatter tftypes.Value
+		allowNeverExpire  tftypes.Value
+		expected          map[string]string
+		expectedPath      path.Path
+	}{
+		{
+			name:              "valid",
+			expireByFormatter: expireByFormatter(map[string]string{"syntaxhighlighting": "1month"}),
+			allowNeverExpire:  tftypes.NewValue(tftypes.Bool, nil),
+			expected:          map[string]string{"syntaxhighlighting": "1month"},
+		},
+		{
+			name:              "unknown formatter",
+			expireByFormatter: expireByFormatter(map[string]string{"latex": "1month"}),
+			allowNeverExpire:  tftypes.NewValue(tftypes.Bool, nil),
+			expectedPath:      path.Root("expire_by_formatter").AtMapKey("latex"),
+		},
+		{
+			name:              "unknown expiration",
+			expireByFormatter: expireByFormatter(map[string]string{"markdown": "2weeks"}),
+			allowNeverExpire:  tftypes.NewValue(tftypes.Bool, nil),
+			expectedPath:      path.Root("expire_by_formatter").AtMapKey("markdown"),
+		},
+		{
+			name:              "never forbidden",
+			expireByFormatter: expireByFormatter(map[string]string{"markdown": "never"}),
+			allowNeverExpire:  tftypes.NewValue(tftypes.Bool, false),
+			expectedPath:      path.Root("expire_by_formatter").AtMapKey("markdown"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PastebinProvider{version: "test"}
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host":                tftypes.NewValue(tftypes.String, "https://example.com"),
+					"expire_by_formatter": tt.expireByFormatter,
+					"allow_never_expire":  tt.allowNeverExpire,
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if tt.expected == nil {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedPath, diagWithPath.Path())
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError())
+			providerData, ok := resp.ResourceData.(*ProviderData)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, providerData.ExpireByFormatter)
+		})
+	}
+}
+
+func TestProviderData_DefaultExpire(t *testing.T) {
+	p := &ProviderData{
+		Expire:            "1week",
+		ExpireByFormatter: map[string]string{"syntaxhighlighting": "1month"},
+	}
+
+	assert.Equal(t, "1month", p.defaultExpire("syntaxhighlighting"))
+	assert.Equal(t, "1week", p.defaultExpire("plaintext"))
+}
+
+func TestPastebinProvider_Configure_InsecureHTTP(t *testing.T) {
+	tests := []struct {
+		name              string
+		host              string
+		allowInsecureHTTP tftypes.Value
+		expectError       bool
+	}{
+		{
+			name:              "http rejected by default",
+			host:              "http://localhost:8080",
+			allowInsecureHTTP: tftypes.NewValue(tftypes.Bool, nil),
+			expectError:       true,
+		},
+		{
+			name:              "http rejected when not allowed",
+			host:              "http://localhost:8080",
+			allowInsecureHTTP: tftypes.NewValue(tftypes.Bool, false),
+			expectError:       true,
+		},
+		{
+			name:              "http allowed",
+			host:              "http://localhost:8080",
+			allowInsecureHTTP: tftypes.NewValue(tftypes.Bool, true),
+			expectError:       false,
+		},
+		{
+			name:              "https",
+			host:              "https://example.com",
+			allowInsecureHTTP: tftypes.NewValue(tftypes.Bool, nil),
+			expectError:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PastebinProvider{version: "test"}
+			ctx := context.Background()
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host":                tftypes.NewValue(tftypes.String, tt.host),
+					"allow_insecure_http": tt.allowInsecureHTTP,
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(ctx, req, resp)
+
+			if tt.expectError {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Insecure Pastebin Host", resp.Diagnostics.Errors()[0].Summary())
+				assert.Nil(t, resp.ResourceData)
+			} else {
+				assert.False(t, resp.Diagnostics.HasError())
+				assert.NotNil(t, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestPastebinProvider_Configure_BoolDefaults(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, "https://example.com"),
+			"gzip":            tftypes.NewValue(tftypes.Bool, false),
+			"open_discussion": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+
+	// An explicit false is kept apart from an unset value
+	require.NotNil(t, providerData.GZip)
+	assert.False(t, *providerData.GZip)
+	require.NotNil(t, providerData.OpenDiscussion)
+	assert.True(t, *providerData.OpenDiscussion)
+	assert.Nil(t, providerData.BurnAfterReading)
+}
+
+func TestResolveBool(t *testing.T) {
+	unset := (*bool)(nil)
+	providerFalse := boolPointer(types.BoolValue(false))
+	providerTrue := boolPointer(types.BoolValue(true))
+
+	tests := []struct {
+		name            string
+		value           types.Bool
+		providerDefault *bool
+		expected        bool
+	}{
+		{"null, provider unset", types.BoolNull(), unset, true},
+		{"null, provider false", types.BoolNull(), providerFalse, false},
+		{"null, provider true", types.BoolNull(), providerTrue, true},
+		{"false, provider unset", types.BoolValue(false), unset, false},
+		{"false, provider false", types.BoolValue(false), providerFalse, false},
+		{"false, provider true", types.BoolValue(false), providerTrue, false},
+		{"true, provider unset", types.BoolValue(true), unset, true},
+		{"true, provider false", types.BoolValue(true), providerFalse, true},
+		{"true, provider true", types.BoolValue(true), providerTrue, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveBool(tt.value, tt.providerDefault, true))
+		})
+	}
+
+	// The fallback only applies when both are unset
+	assert.False(t, resolveBool(types.BoolNull(), unset, false))
+}
+
+func TestPastebinProvider_Configure_Hosts(t *testing.T) {
+	hostsValue := func(hosts ...string) tftypes.Value {
+		var values []tftypes.Value
+		for _, h := range hosts {
+			values = append(values, tftypes.NewValue(tftypes.String, h))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	tests := []struct {
+		name             string
+		host             tftypes.Value
+		hosts            tftypes.Value
+		expectedEndpoint string
+		expectedMirrors  []string
+		expectError      bool
+	}{
+		{
+			name:             "host and mirrors",
+			host:             tftypes.NewValue(tftypes.String, "https://primary.example.com"),
+			hosts:            hostsValue("https://mirror1.example.com", "https://mirror2.example.com"),
+			expectedEndpoint: "https://primary.example.com",
+			expectedMirrors:  []string{"https://mirror1.example.com", "https://mirror2.example.com"},
+		},
+		{
+			name:             "hosts only",
+			host:             tftypes.NewValue(tftypes.String, nil),
+			hosts:            hostsValue("https://primary.example.com", "https://mirror1.example.com"),
+			expectedEndpoint: "https://primary.example.com",
+			expectedMirrors:  []string{"https://mirror1.example.com"},
+		},
+		{
+			name:        "invalid mirror",
+			host:        tftypes.NewValue(tftypes.String, "https://primary.example.com"),
+			hosts:       hostsValue("mirror1.example.com"),
+			expectError: true,
+		},
+		{
+			name:        "insecure mirror",
+			host:        tftypes.NewValue(tftypes.String, "https://primary.example.com"),
+			hosts:       hostsValue("http://mirror1.example.com"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PASTEBIN_HOST", "")
+
+			p := &PastebinProvider{version: "test"}
+			ctx := context.Background()
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host":  tt.host,
+					"hosts": tt.hosts,
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(ctx, req, resp)
+
+			if tt.expectError {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Nil(t, resp.ResourceData)
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError())
+			providerData, ok := resp.ResourceData.(*ProviderData)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedEndpoint, providerData.Endpoint.String())
+
+			var mirrors []string
+			for _, mirror := range providerData.Mirrors {
+				mirrors = append(mirrors, mirror.String())
+			}
+			assert.Equal(t, tt.expectedMirrors, mirrors)
+			assert.Len(t, providerData.hostClients(nil), len(tt.expectedMirrors)+1)
+		})
+	}
+}
+
+func TestPastebinProvider_Configure_CircuitBreaker(t *testing.T) {
+	breakerValue := func(threshold interface{}, window, cooldown interface{}) tftypes.Value {
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"threshold": tftypes.Number,
+			"window":    tftypes.String,
+			"cooldown":  tftypes.String,
+		}}, map[string]tftypes.Value{
+			"threshold": tftypes.NewValue(tftypes.Number, threshold),
+			"window":    tftypes.NewValue(tftypes.String, window),
+			"cooldown":  tftypes.NewValue(tftypes.String, cooldown),
+		})
+	}
+
+	tests := []struct {
+		name             string
+		breaker          tftypes.Value
+		expectedBreakers int
+		expectedCooldown time.Duration
+		expectedPath     path.Path
+	}{
+		{
+			name:             "defaults",
+			breaker:          breakerValue(nil, nil, nil),
+			expectedBreakers: 2,
+			expectedCooldown: defaultBreakerCooldown,
+		},
+		{
+			name:             "custom",
+			breaker:          breakerValue(3, "2m", "1m"),
+			expectedBreakers: 2,
+			expectedCooldown: time.Minute,
+		},
+		{
+			name:    "disabled",
+			breaker: breakerValue(0, nil, nil),
+		},
+		{
+			name:         "invalid duration",
+			breaker:      breakerValue(nil, nil, "soon"),
+			expectedPath: path.Root("circuit_breaker").AtName("cooldown"),
+		},
+		{
+			name:         "negative duration",
+			breaker:      breakerValue(nil, "-1m", nil),
+			expectedPath: path.Root("circuit_breaker").AtName("window"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PastebinProvider{version: "test"}
+
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host": tftypes.NewValue(tftypes.String, "https://primary.example.com"),
+					"hosts": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+						tftypes.NewValue(tftypes.String, "https://mirror.example.com"),
+					}),
+					"circuit_breaker": tt.breaker,
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if len(tt.expectedPath.Steps()) > 0 {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				diagWithPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedPath, diagWithPath.Path())
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError())
+			providerData, ok := resp.ResourceData.(*ProviderData)
+			require.True(t, ok)
+			require.Len(t, providerData.breakers, tt.expectedBreakers)
+			for i, hc := range providerData.hostClients(nil) {
+				assert.Same(t, providerData.breaker(i), hc.breaker)
+			}
+			if tt.expectedBreakers > 0 {
+				assert.Equal(t, tt.expectedCooldown, providerData.breakers[0].cooldown)
+			}
+		})
+	}
+}
+
+func TestPastebinProvider_Configure_HeaderOrder(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":   tftypes.NewValue(tftypes.String, "https://example.com"),
+			"locale": tftypes.NewValue(tftypes.String, "de-CH"),
+			"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"X-B": tftypes.NewValue(tftypes.String, "b"),
+				"X-A": tftypes.NewValue(tftypes.String, "a"),
+			}),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	assert.Equal(t, []headerField{
+		{Name: "Accept-Language", Value: "de-CH"},
+		{Name: "X-A", Value: "a"},
+		{Name: "X-B", Value: "b"},
+	}, providerData.Headers)
+}
+
+func TestParseHeaderLines(t *testing.T) {
+	fields, malformed := parseHeaderLines("X-Team: platform\r\n\n  Authorization:  Bearer abc:def  \nno colon\n: empty name\nBad Name: value\nX-Empty:")
+
+	assert.Equal(t, []headerField{
+		{Name: "X-Team", Value: "platform"},
+		{Name: "Authorization", Value: "Bearer abc:def"},
+		{Name: "X-Empty", Value: ""},
+	}, fields)
+	assert.Equal(t, []int{4, 5, 6}, malformed)
+}
+
+func TestPastebinProvider_Configure_EnvExtraHeaders(t *testing.T) {
+	t.Setenv("PASTEBIN_EXTRA_HEADERS", "X-Team: from-env\nx-ci-job: 42\nmalformed line")
+
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "https://example.com"),
+			"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"x-team": tftypes.NewValue(tftypes.String, "from-config"),
+			}),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "Malformed Extra Headers", resp.Diagnostics.Warnings()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "were skipped: 3.")
+
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	assert.Equal(t, []headerField{
+		{Name: "x-ci-job", Value: "42"},
+		{Name: "x-team", Value: "from-config"},
+	}, providerData.Headers)
+}
+
+func TestPastebinProvider_Configure_AcceptEncodingHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string
+		values map[string]tftypes.Value
+	}{
+		{
+			name: "extra_headers",
+			values: map[string]tftypes.Value{
+				"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"accept-encoding": tftypes.NewValue(tftypes.String, "gzip"),
+				}),
+			},
+		},
+		{
+			name: "environment",
+			env:  "Accept-Encoding: br",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PASTEBIN_EXTRA_HEADERS", tt.env)
+
+			values := map[string]tftypes.Value{
+				"host": tftypes.NewValue(tftypes.String, "https://example.com"),
+			}
+			for name, value := range tt.values {
+				values[name] = value
+			}
+
+			p := &PastebinProvider{version: "test"}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: testProviderConfig(t, values)}, resp)
+
+			require.Len(t, resp.Diagnostics.Errors(), 1)
+			assert.Equal(t, "Unsupported Extra Header", resp.Diagnostics.Errors()[0].Summary())
+			assert.Nil(t, resp.ResourceData)
+		})
+	}
+}
+
+func TestPastebinProvider_Configure_EnvDefaults(t *testing.T) {
+	t.Setenv("PASTEBIN_EXPIRE", "1day")
+	t.Setenv("PASTEBIN_FORMATTER", "markdown")
+	t.Setenv("PASTEBIN_GZIP", "false")
+	t.Setenv("PASTEBIN_OPEN_DISCUSSION", "1")
+	t.Setenv("PASTEBIN_BURN_AFTER_READING", "true")
+	t.Setenv("PASTEBIN_USER_AGENT", "ci-bot/1.0")
+	t.Setenv("PASTEBIN_SKIP_TLS_VERIFY", "")
+
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":      tftypes.NewValue(tftypes.String, "https://example.com"),
+			"formatter": tftypes.NewValue(tftypes.String, "syntaxhighlighting"),
+			"gzip":      tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	assert.Empty(t, resp.Diagnostics.Warnings())
+
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	assert.Equal(t, "1day", providerData.Expire)
+	// Attributes set in the configuration take precedence
+	assert.Equal(t, "syntaxhighlighting", providerData.Formatter)
+	require.NotNil(t, providerData.GZip)
+	assert.True(t, *providerData.GZip)
+	require.NotNil(t, providerData.OpenDiscussion)
+	assert.True(t, *providerData.OpenDiscussion)
+	require.NotNil(t, providerData.BurnAfterReading)
+	assert.True(t, *providerData.BurnAfterReading)
+}
+
+func TestPastebinProvider_Configure_InvalidEnvBool(t *testing.T) {
+	t.Setenv("PASTEBIN_GZIP", "maybe")
+	t.Setenv("PASTEBIN_SKIP_TLS_VERIFY", "yes")
+
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "https://example.com"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.Len(t, resp.Diagnostics.Errors(), 2)
+	var paths []path.Path
+	for _, d := range resp.Diagnostics.Errors() {
+		assert.Equal(t, "Invalid Environment Variable", d.Summary())
+		diagWithPath, ok := d.(diag.DiagnosticWithPath)
+		require.True(t, ok)
+		paths = append(paths, diagWithPath.Path())
+	}
+	assert.Equal(t, []path.Path{path.Root("gzip"), path.Root("skip_tls_verify")}, paths)
+	assert.Nil(t, resp.ResourceData)
+}
+
+func TestPastebinProvider_Configure_SkipTLSVerifyWarning(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host":            tftypes.NewValue(tftypes.String, "https://example.com"),
+			"skip_tls_verify": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "TLS Verification Disabled", resp.Diagnostics.Warnings()[0].Summary())
+	assert.NotNil(t, resp.ResourceData)
+}
+
+func TestPastebinProvider_Configure_ExtraHeadersList(t *testing.T) {
+	p := &PastebinProvider{version: "test"}
+	ctx := context.Background()
+
+	headerType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":  tftypes.String,
+		"value": tftypes.String,
+	}}
+	header := func(name, value string) tftypes.Value {
+		return tftypes.NewValue(headerType, map[string]tftypes.Value{
+			"name":  tftypes.NewValue(tftypes.String, name),
+			"value": tftypes.NewValue(tftypes.String, value),
+		})
+	}
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "https://example.com"),
+			"extra_headers_list": tftypes.NewValue(tftypes.List{ElementType: headerType}, []tftypes.Value{
+				header("X-Forwarded-For", "10.0.0.1"),
+				header("X-Forwarded-For", "10.0.0.2"),
+			}),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	assert.NotNil(t, resp.ResourceData)
+}
+
+func TestLocalePattern(t *testing.T) {
+	for _, locale := range []string{"en", "pt-BR", "zh-Hant-TW", "de-CH-1996"} {
+		assert.True(t, localePattern.MatchString(locale), "expected %q to match", locale)
+	}
+
+	for _, locale := range []string{"", "e", "en_US", "en-", "-en", "en US"} {
+		assert.False(t, localePattern.MatchString(locale), "expected %q not to match", locale)
+	}
+}
+
+func TestProviderData_ClientWithHeaders(t *testing.T) {
+	endpoint, _ := url.Parse("https://example.com")
+	providerData := &ProviderData{
+		Endpoint: *endpoint,
+		Headers:  []headerField{{Name: "X-Feature", Value: "off"}},
+	}
+	providerData.Client = providerData.newClient(nil)
+
+	assert.Same(t, providerData.Client, providerData.clientWithHeaders(nil))
+	assert.Same(t, providerData.Client, providerData.clientWithHeaders(map[string]string{}))
+
+	client := providerData.clientWithHeaders(map[string]string{"x-feature": "on"})
+	assert.NotNil(t, client)
+	assert.NotSame(t, providerData.Client, client)
+}
+
+func TestNoControlCharactersPattern(t *testing.T) {
+	for _, value := range []string{"", "ci-pipeline/42", "team (platform)"} {
+		assert.True(t, noControlCharactersPattern.MatchString(value), "expected %q to match", value)
+	}
+
+	for _, value := range []string{"line\nbreak", "carriage\rreturn", "tab\t", "nul\x00", "del\x7f"} {
+		assert.False(t, noControlCharactersPattern.MatchString(value), "expected %q not to match", value)
+	}
+}
+
+func TestProviderData_PasteURL(t *testing.T) {
+	endpoint, _ := url.Parse("https://example.com/pastebin/")
+	providerData := &ProviderData{Endpoint: *endpoint}
+
+	pasteURL := providerData.pasteURL("abcd1234", "EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF")
+
+	assert.Equal(t, "https://example.com/pastebin/?abcd1234#EezApNVTTRUuEkt1jj7r9vSfewLBvUohDSXWuvPEs1bF", pasteURL.String())
+	assert.Equal(t, "https://example.com/pastebin/", providerData.Endpoint.String())
+}
+
+func TestHasHeader(t *testing.T) {
+	headers := map[string]string{"accept-language": "fr"}
+	headersList := []HeaderModel{
+		{Name: types.StringValue("X-Forwarded-For"), Value: types.StringValue("10.0.0.1")},
+	}
+
+	assert.True(t, hasHeader(headers, nil, "Accept-Language"))
+	assert.True(t, hasHeader(nil, headersList, "x-forwarded-for"))
+	assert.False(t, hasHeader(headers, headersList, "Authorization"))
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := testCertificatePEM(t)
+	otherCertPEM, _ := testCertificatePEM(t)
+
+	t.Run("defaults", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{})
+		assert.False(t, diags.HasError())
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("skip TLS verify", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{SkipTLSVerify: types.BoolValue(true)})
+		assert.False(t, diags.HasError())
+		require.NotNil(t, tlsConfig)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{
+			ClientCertPEM: types.StringValue(certPEM),
+			ClientKeyPEM:  types.StringValue(keyPEM),
+		})
+		assert.False(t, diags.HasError())
+		require.NotNil(t, tlsConfig)
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("CA bundle", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{CACertPEM: types.StringValue(certPEM)})
+		assert.False(t, diags.HasError())
+		require.NotNil(t, tlsConfig)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("CA bundle without certificates", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{CACertPEM: types.StringValue("not a certificate")})
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Invalid CA Certificate", diags.Errors()[0].Summary())
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("minimum TLS version", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{
+			MinTLSVersion: types.StringValue("1.3"),
+			CACertPEM:     types.StringValue(certPEM),
+		})
+		assert.False(t, diags.HasError())
+		require.NotNil(t, tlsConfig)
+		assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("unsupported minimum TLS version", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{MinTLSVersion: types.StringValue("1.1")})
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Invalid Minimum TLS Version", diags.Errors()[0].Summary())
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("mismatched client certificate and key", func(t *testing.T) {
+		tlsConfig, diags := newTLSConfig(PastebinProviderModel{
+			ClientCertPEM: types.StringValue(otherCertPEM),
+			ClientKeyPEM:  types.StringValue(keyPEM),
+		})
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Invalid Client Certificate", diags.Errors()[0].Summary())
+		assert.Nil(t, tlsConfig)
+	})
+}
+
 func TestPastebinProvider_Resources(t *testing.T) {
 	p := &PastebinProvider{}
 	ctx := context.Background()
@@ -177,13 +1140,39 @@ func TestPastebinProvider_DataSources(t *testing.T) {
 
 	dataSources := p.DataSources(ctx)
 
-	assert.Len(t, dataSources, 1)
+	assert.Len(t, dataSources, 5)
 	
 	// Test that the data source factory function works
 	dataSource := dataSources[0]()
 	assert.NotNil(t, dataSource)
 }
 
+func TestPastebinProvider_EphemeralResources(t *testing.T) {
+	p := &PastebinProvider{}
+	ctx := context.Background()
+
+	ephemeralResources := p.EphemeralResources(ctx)
+
+	assert.Len(t, ephemeralResources, 1)
+
+	// Test that the ephemeral resource factory function works
+	ephemeralResource := ephemeralResources[0]()
+	assert.NotNil(t, ephemeralResource)
+}
+
+func TestPastebinProvider_Functions(t *testing.T) {
+	p := &PastebinProvider{}
+	ctx := context.Background()
+
+	functions := p.Functions(ctx)
+
+	assert.Len(t, functions, 4)
+
+	// Test that the function factory works
+	fn := functions[0]()
+	assert.NotNil(t, fn)
+}
+
 func TestNew(t *testing.T) {
 	version := "1.2.3"
 	providerFactory := New(version)
@@ -195,6 +1184,31 @@ func TestNew(t *testing.T) {
 	pastebinProvider, ok := provider.(*PastebinProvider)
 	assert.True(t, ok)
 	assert.Equal(t, version, pastebinProvider.version)
+	assert.Nil(t, pastebinProvider.onRequest)
+}
+
+func TestNewWithRequestHook(t *testing.T) {
+	var ops []string
+	p := NewWithRequestHook("test", func(op string, dur time.Duration, err error) {
+		ops = append(ops, op)
+	})()
+
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, map[string]tftypes.Value{
+			"host": tftypes.NewValue(tftypes.String, "https://example.com"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	providerData, ok := resp.ResourceData.(*ProviderData)
+	require.True(t, ok)
+	require.NotNil(t, providerData.OnRequest)
+
+	providerData.OnRequest(opShowPaste, time.Second, nil)
+	assert.Equal(t, []string{opShowPaste}, ops)
 }
 
 // testProviderFactory creates a provider factory for use in tests
@@ -223,16 +1237,16 @@ func TestProviderData_Defaults(t *testing.T) {
 			data: ProviderData{
 				Expire:           "1day",
 				Formatter:        "markdown",
-				GZip:             true,
-				OpenDiscussion:   true,
-				BurnAfterReading: true,
+				GZip:             boolPointer(types.BoolValue(true)),
+				OpenDiscussion:   boolPointer(types.BoolValue(true)),
+				BurnAfterReading: boolPointer(types.BoolValue(true)),
 			},
 			expected: ProviderData{
 				Expire:           "1day",
 				Formatter:        "markdown",
-				GZip:             true,
-				OpenDiscussion:   true,
-				BurnAfterReading: true,
+				GZip:             boolPointer(types.BoolValue(true)),
+				OpenDiscussion:   boolPointer(types.BoolValue(true)),
+				BurnAfterReading: boolPointer(types.BoolValue(true)),
 			},
 		},
 	}
@@ -248,6 +1262,56 @@ func TestProviderData_Defaults(t *testing.T) {
 	}
 }
 
+// testProviderConfig builds a provider configuration with the given
+// attribute values, leaving every other attribute null.
+func testProviderConfig(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &provider.SchemaResponse{}
+	(&PastebinProvider{}).Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		attrs[name] = value
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attrs),
+	}
+}
+
+// testCertificatePEM generates a self-signed certificate and its private
+// key, both PEM encoded.
+func testCertificatePEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-pastebin test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
 // Helper functions for environment variable testing
 func setEnv(key, value string) {
 	if value == "" {
@@ -272,4 +1336,32 @@ func mustParseURL(urlStr string) *url.URL {
 		panic(err)
 	}
 	return u
-}
\ No newline at end of file
+}
+
+func TestPastebinProvider_Configure_MaxConcurrentRequests(t *testing.T) {
+	configure := func(maxConcurrent interface{}) *ProviderData {
+		p := &PastebinProvider{version: "test"}
+		req := provider.ConfigureRequest{
+			Config: testProviderConfig(t, map[string]tftypes.Value{
+				"host":                    tftypes.NewValue(tftypes.String, "https://example.com"),
+				"max_concurrent_requests": tftypes.NewValue(tftypes.Number, maxConcurrent),
+			}),
+		}
+		resp := &provider.ConfigureResponse{}
+
+		p.Configure(context.Background(), req, resp)
+
+		require.False(t, resp.Diagnostics.HasError())
+		providerData, ok := resp.ResourceData.(*ProviderData)
+		require.True(t, ok)
+		return providerData
+	}
+
+	assert.Nil(t, configure(nil).requestSlots)
+
+	providerData := configure(4)
+	assert.Equal(t, 4, cap(providerData.requestSlots))
+	for _, hc := range providerData.hostClients(nil) {
+		assert.Equal(t, providerData.requestSlots, hc.slots)
+	}
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// versionConstraintPattern matches one comparison of a version constraint,
+// such as ">= 1.7" or "~> 1.7.1".
+var versionConstraintPattern = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>)?\s*v?([0-9]+(?:\.[0-9]+)*)$`)
+
+// serverVersionPattern matches the numeric part of an instance version,
+// ignoring pre-release and build suffixes such as -rc1.
+var serverVersionPattern = regexp.MustCompile(`^v?([0-9]+(?:\.[0-9]+)*)`)
+
+// versionConstraint is one comparison of required_server_version.
+type versionConstraint struct {
+	op      string
+	version []int
+}
+
+// parseVersionConstraints parses comma separated comparisons in the syntax
+// of Terraform version constraints, such as ">= 1.7, < 2.0" or "~> 1.7".
+// A version without an operator must match exactly.
+func parseVersionConstraints(s string) ([]versionConstraint, error) {
+	var constraints []versionConstraint
+	for _, part := range strings.Split(s, ",") {
+		m := versionConstraintPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, fmt.Errorf("expected comparisons such as \">= 1.7, < 2.0\" or \"~> 1.7\", got: %q", strings.TrimSpace(part))
+		}
+
+		op := m[1]
+		if op == "" {
+			op = "="
+		}
+
+		version, err := parseVersionNumbers(m[2])
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, versionConstraint{op: op, version: version})
+	}
+
+	return constraints, nil
+}
+
+// parseServerVersion parses the version reported by an instance.
+func parseServerVersion(s string) ([]int, error) {
+	m := serverVersionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("unrecognized version %q", s)
+	}
+
+	return parseVersionNumbers(m[1])
+}
+
+func parseVersionNumbers(s string) ([]int, error) {
+	fields := strings.Split(s, ".")
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		numbers[i] = n
+	}
+
+	return numbers, nil
+}
+
+// compareVersions returns -1, 0 or 1 as a is lower than, equal to or
+// greater than b. Missing trailing numbers count as 0, so 1.7 equals 1.7.0.
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// allows reports whether version satisfies the comparison. ~> allows only
+// the last given number to increase, so ~> 1.7 allows 1.8 but not 2.0, and
+// ~> 1.7.1 allows 1.7.5 but not 1.8.
+func (c versionConstraint) allows(version []int) bool {
+	cmp := compareVersions(version, c.version)
+
+	switch c.op {
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		if cmp < 0 {
+			return false
+		}
+
+		prefix := len(c.version) - 1
+		if prefix == 0 {
+			prefix = 1
+		}
+		for i := 0; i < prefix; i++ {
+			var n int
+			if i < len(version) {
+				n = version[i]
+			}
+			if n != c.version[i] {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return cmp == 0
+	}
+}
+
+// checkServerVersion probes the version of the instance and adds an error
+// to diags when it does not satisfy constraint. An instance that cannot be
+// reached or does not report its version only adds a warning, since the
+// check cannot be made.
+func (p *ProviderData) checkServerVersion(ctx context.Context, constraint string, constraints []versionConstraint, diags *diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	result := checkHealth(ctx, newRedirectClient(p.httpTransport()), p.Endpoint, p.Headers)
+
+	var reason string
+	switch {
+	case !result.Reachable:
+		reason = "the instance could not be reached: " + p.redactError(result.Err)
+	case result.Version == "":
+		reason = "the instance does not report its version"
+	}
+
+	var version []int
+	if reason == "" {
+		var err error
+		if version, err = parseServerVersion(result.Version); err != nil {
+			reason = err.Error()
+		}
+	}
+
+	if reason != "" {
+		diags.AddAttributeWarning(
+			path.Root("required_server_version"),
+			"Server Version Not Checked",
+			fmt.Sprintf("The version of %s could not be checked against %q, because %s.", p.Endpoint.Redacted(), constraint, reason),
+		)
+		return
+	}
+
+	for _, c := range constraints {
+		if !c.allows(version) {
+			diags.AddAttributeError(
+				path.Root("required_server_version"),
+				"Unsupported Server Version",
+				fmt.Sprintf("%s runs PrivateBin %s, which does not satisfy required_server_version %q.", p.Endpoint.Redacted(), result.Version, constraint),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionConstraints(t *testing.T) {
+	tests := []struct {
+		constraint string
+		allowed    []string
+		rejected   []string
+	}{
+		{"1.7.1", []string{"1.7.1", "v1.7.1"}, []string{"1.7.0", "1.7.2"}},
+		{"= 1.7", []string{"1.7", "1.7.0"}, []string{"1.7.1"}},
+		{"!= 1.7.1", []string{"1.7.0"}, []string{"1.7.1"}},
+		{">= 1.7, < 2.0", []string{"1.7.0", "1.9.9", "1.7.1-rc1"}, []string{"1.6.9", "2.0.0"}},
+		{"> 1.7, <= 1.8", []string{"1.7.1", "1.8"}, []string{"1.7", "1.8.1"}},
+		{"~> 1.7", []string{"1.7", "1.8.2"}, []string{"1.6", "2.0"}},
+		{"~> 1.7.1", []string{"1.7.1", "1.7.9"}, []string{"1.7.0", "1.8.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			constraints, err := parseVersionConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			allows := func(v string) bool {
+				version, err := parseServerVersion(v)
+				require.NoError(t, err)
+				for _, c := range constraints {
+					if !c.allows(version) {
+						return false
+					}
+				}
+				return true
+			}
+
+			for _, v := range tt.allowed {
+				assert.True(t, allows(v), v)
+			}
+			for _, v := range tt.rejected {
+				assert.False(t, allows(v), v)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "latest", ">= 1.7,", "=> 1.7", "1.x"} {
+		_, err := parseVersionConstraints(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPastebinProvider_Configure_RequiredServerVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		constraint      string
+		expectedError   string
+		expectedWarning string
+	}{
+		{name: "satisfied", version: "1.7.1", constraint: ">= 1.7, < 2.0"},
+		{name: "not satisfied", version: "1.6.2", constraint: "~> 1.7", expectedError: "Unsupported Server Version"},
+		{name: "version not reported", constraint: "~> 1.7", expectedWarning: "Server Version Not Checked"},
+		{name: "invalid constraint", version: "1.7.1", constraint: "latest", expectedError: "Invalid Version Constraint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakePrivateBin(t)
+			fake.version = tt.version
+
+			p := &PastebinProvider{version: "test"}
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{
+				Config: testProviderConfig(t, map[string]tftypes.Value{
+					"host":                    tftypes.NewValue(tftypes.String, fake.URL),
+					"allow_insecure_http":     tftypes.NewValue(tftypes.Bool, true),
+					"required_server_version": tftypes.NewValue(tftypes.String, tt.constraint),
+				}),
+			}, resp)
+
+			if tt.expectedError != "" {
+				require.Len(t, resp.Diagnostics.Errors(), 1)
+				assert.Equal(t, tt.expectedError, resp.Diagnostics.Errors()[0].Summary())
+				assert.Nil(t, resp.ResourceData)
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.NotNil(t, resp.ResourceData)
+
+			if tt.expectedWarning != "" {
+				require.Len(t, resp.Diagnostics.Warnings(), 1)
+				assert.Equal(t, tt.expectedWarning, resp.Diagnostics.Warnings()[0].Summary())
+			} else {
+				assert.Empty(t, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
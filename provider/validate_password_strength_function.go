@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidatePasswordStrengthFunction{}
+
+const (
+	// minPasswordLength is the minimum number of characters of a strong
+	// password.
+	minPasswordLength = 12
+
+	// minPasswordClasses is the minimum number of character classes, out
+	// of lowercase letters, uppercase letters, digits and other
+	// characters, a strong password uses.
+	minPasswordClasses = 3
+)
+
+func NewValidatePasswordStrengthFunction() function.Function {
+	return &ValidatePasswordStrengthFunction{}
+}
+
+// ValidatePasswordStrengthFunction defines the function implementation.
+type ValidatePasswordStrengthFunction struct{}
+
+func (f *ValidatePasswordStrengthFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_password_strength"
+}
+
+func (f *ValidatePasswordStrengthFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether a paste password is strong",
+		MarkdownDescription: fmt.Sprintf("Returns whether `password` has at least %d characters and uses at least %d of "+
+			"lowercase letters, uppercase letters, digits and other characters. "+
+			"Use it in a `precondition` to enforce a password policy before creating a protected paste.",
+			minPasswordLength, minPasswordClasses),
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "password",
+				MarkdownDescription: "Password to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *ValidatePasswordStrengthFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var password string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &password))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, isStrongPassword(password)))
+}
+
+// isStrongPassword reports whether password is at least minPasswordLength
+// characters long and uses at least minPasswordClasses character classes.
+func isStrongPassword(password string) bool {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return false
+	}
+
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, used := range []bool{lower, upper, digit, other} {
+		if used {
+			classes++
+		}
+	}
+
+	return classes >= minPasswordClasses
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePasswordStrengthFunction_Metadata(t *testing.T) {
+	f := &ValidatePasswordStrengthFunction{}
+	resp := &function.MetadataResponse{}
+
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	assert.Equal(t, "validate_password_strength", resp.Name)
+}
+
+func TestValidatePasswordStrengthFunction_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		expected bool
+	}{
+		{"strong", "Correct-Horse-7", true},
+		{"three classes without symbols", "CorrectHorse7", true},
+		{"too short", "Sh0rt-Pass", false},
+		{"lowercase only", "correcthorsebattery", false},
+		{"two classes", "correcthorse77", false},
+		{"counts characters, not bytes", "ÄÖÜäöü-ÄÖÜä", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &ValidatePasswordStrengthFunction{}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.password),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.BoolValue(tt.expected), resp.Result.Value())
+		})
+	}
+}